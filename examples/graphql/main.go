@@ -59,8 +59,12 @@ func init() {
 		panic(err)
 	}
 
-	// Configurar o handler GraphQL
-	wrappedHandler = api.NewHandler(true)
+	// Configurar o handler GraphQL e as verificações de saúde
+	mux := http.NewServeMux()
+	mux.Handle(api.Config.Route, api.NewHandler(true))
+	mux.Handle("/health", api.NewLivenessHandler())
+	mux.Handle("/ready", api.NewReadinessHandler(0))
+	wrappedHandler = mux
 
 	// Adaptar o handler para Lambda
 	adapter = api.ToAmazonALB(wrappedHandler)
@@ -70,14 +74,8 @@ func requestHandler(ctx context.Context, req events.ALBTargetGroupRequest) (even
 	method := req.HTTPMethod
 	path := req.Path
 
-	if path == "/health" && method == http.MethodGet {
-		return events.ALBTargetGroupResponse{
-			StatusCode: http.StatusOK,
-			Body:       `{"status": "ok"}`,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-		}, nil
+	if (path == "/health" || path == "/ready") && (method == http.MethodGet || method == http.MethodHead) {
+		return adapter.ProxyWithContext(ctx, req)
 
 	} else if path != api.Config.Route && method != http.MethodPost {
 		return events.ALBTargetGroupResponse{
@@ -96,7 +94,7 @@ func main() {
 	if _, ok := os.LookupEnv("ENVIRONMENT"); ok {
 		lambda.Start(requestHandler)
 	} else {
-		http.Handle("/graphql", wrappedHandler)
+		http.Handle("/", wrappedHandler)
 		fmt.Println("Server running at http://localhost:8080/graphql")
 		log.Fatal(http.ListenAndServe(":8080", nil))
 	}
@@ -1,11 +1,18 @@
 package adapters
 
-import "regexp"
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
 
 var re *regexp.Regexp = regexp.MustCompile(`({.+})`)
 
+// Adapter is the contract implemented by every data source used by the GraphQL
+// connectors. The context carries the request deadline and cancellation signal,
+// so implementations must hand it to the underlying client.
 type Adapter interface {
-	GetData(args []AdapterAttribute) (interface{}, error)
+	GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error)
 	GetParameters(args map[string]interface{}) ([]AdapterAttribute, error)
 }
 
@@ -15,20 +22,31 @@ type AdapterAttribute struct {
 	Value interface{}
 }
 
+// getParameters resolves the arguments declared in the attributes, coercing each
+// value to its declared type. Required arguments (e.g. "Int!") must be informed.
 func getParameters(attributes map[string]interface{}, args map[string]interface{}) ([]AdapterAttribute, error) {
 	params := make([]AdapterAttribute, 0)
 	for key, valueType := range attributes {
-		attribute := AdapterAttribute{
-			Name:  key,
-			Type:  valueType.(string),
-			Value: nil,
+		declaration, ok := valueType.(string)
+		if !ok {
+			return nil, &ArgumentError{Name: key, Type: fmt.Sprintf("%v", valueType), Reason: "the declared type must be a string"}
+		}
+
+		kind, err := parseArgumentType(declaration)
+		if err != nil {
+			return nil, &ArgumentError{Name: key, Type: declaration, Reason: err.Error()}
 		}
 
-		if value, exists := args[attribute.Name]; exists {
-			attribute.Value = value
+		value, err := kind.coerce(args[key])
+		if err != nil {
+			return nil, &ArgumentError{Name: key, Type: declaration, Reason: err.Error()}
 		}
 
-		params = append(params, attribute)
+		params = append(params, AdapterAttribute{
+			Name:  key,
+			Type:  declaration,
+			Value: value,
+		})
 	}
 	return params, nil
 }
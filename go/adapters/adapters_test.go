@@ -1,6 +1,8 @@
 package adapters
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -123,7 +125,8 @@ func TestGetParameters(t *testing.T) {
 // Teste de integração para verificar a interface
 func TestAdapterInterface(t *testing.T) {
 	// Verificar se RedisAdapter implementa Adapter
-	var _ Adapter = NewRedisAdapter("localhost:6379", "", "key", nil)
+	redisAdapter, _ := NewRedisAdapter("localhost:6379", "", "key", nil)
+	var _ Adapter = redisAdapter
 
 	// Verificar se RestAdapter implementa Adapter
 	cfg := &types.Config{AccessToken: "token"}
@@ -193,8 +196,76 @@ func TestRestAdapter_Timeout(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, server.URL, "slow", false, nil, nil)
 
-	_, err := adapter.GetData([]AdapterAttribute{})
+	_, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err == nil {
 		t.Fatal("esperado erro de timeout")
 	}
 }
+
+// Teste para cancelamento via contexto do RestAdapter
+func TestRestAdapter_ContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Aguardar até que o cliente desista da requisição
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	adapter := NewRestAdapter(cfg, server.URL, "slow", false, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := adapter.GetData(ctx, []AdapterAttribute{})
+	if err == nil {
+		t.Fatal("esperado erro de deadline do contexto")
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("GetData levou %v, esperado respeitar o deadline do contexto", elapsed)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("erro = %v, esperado context.DeadlineExceeded", err)
+	}
+}
+
+// Testes para a extração de valores por caminho
+func TestExtractPath(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"a/b":   "escaped",
+			"items": []interface{}{"zero", "one"},
+		},
+	}
+
+	tests := []struct {
+		path        string
+		expected    interface{}
+		expectError bool
+	}{
+		{"$", data, false},
+		{"/data/items/1", "one", false},
+		{"/data/a~1b", "escaped", false},
+		{"$.data.items[0]", "zero", false},
+		{"data.items[1]", "one", false},
+		{"$['data']['a/b']", "escaped", false},
+		{"$.data.items[5]", nil, false},
+		{"$.data.items.x", nil, false},
+		{"$.data[", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, err := ExtractPath(data, tt.path)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("erro = %v, esperado erro %v", err, tt.expectError)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExtractPath(%q) = %v, esperado %v", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ArgumentError reports a GraphQL argument that is missing or does not match
+// the type declared in the "attr" settings of the connector
+type ArgumentError struct {
+	Name   string
+	Type   string
+	Reason string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("invalid argument %s (%s): %s", e.Name, e.Type, e.Reason)
+}
+
+// argumentType is a parsed type declaration, using the GraphQL notation: a
+// trailing "!" marks a required value and brackets declare a list (e.g. "[Int!]!")
+type argumentType struct {
+	name     string
+	required bool
+	elem     *argumentType
+}
+
+// parseArgumentType parses the type declared for an attribute
+func parseArgumentType(declaration string) (*argumentType, error) {
+	text := strings.TrimSpace(declaration)
+	if text == "" {
+		return nil, fmt.Errorf("the type was not informed")
+	}
+
+	kind := &argumentType{}
+	if strings.HasSuffix(text, "!") {
+		kind.required = true
+		text = strings.TrimSpace(strings.TrimSuffix(text, "!"))
+	}
+
+	if strings.HasPrefix(text, "[") {
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated list type %q", declaration)
+		}
+
+		elem, err := parseArgumentType(text[1 : len(text)-1])
+		if err != nil {
+			return nil, err
+		}
+		kind.elem = elem
+		return kind, nil
+	}
+
+	if strings.ContainsAny(text, "[]! ") {
+		return nil, fmt.Errorf("invalid type %q", declaration)
+	}
+	kind.name = strings.ToLower(text)
+	return kind, nil
+}
+
+// coerce converts the value to the declared type. Unknown type names (e.g. custom
+// scalars) keep the value unchanged.
+func (a *argumentType) coerce(value interface{}) (interface{}, error) {
+	if value == nil {
+		if a.required {
+			return nil, fmt.Errorf("a value is required")
+		}
+		return nil, nil
+	}
+
+	if a.elem != nil {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			// a single value is accepted as a list of one item, as in GraphQL
+			item, err := a.elem.coerce(value)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+
+		list := make([]interface{}, 0, items.Len())
+		for i := 0; i < items.Len(); i++ {
+			item, err := a.elem.coerce(items.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %v", i, err)
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	}
+
+	switch a.name {
+	case "int":
+		return coerceInt(value)
+	case "float":
+		return coerceFloat(value)
+	case "string":
+		return coerceString(value)
+	case "boolean":
+		return coerceBoolean(value)
+	case "id":
+		return coerceID(value)
+	default:
+		return value, nil
+	}
+}
+
+func coerceInt(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		number, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		return int(number), nil
+	case json.Number:
+		if number, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return int(number), nil
+		}
+		// numbers in decimal or exponent notation (e.g. 1e3) are checked below
+		number, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		value = number
+	case bool:
+		return nil, fmt.Errorf("expected an integer, got %v", v)
+	}
+
+	// integer kinds are converted directly, since float64 loses precision above 2^53
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("the integer %v is out of range", value)
+		}
+		return int(v.Uint()), nil
+	}
+
+	number, ok := toFloat(value)
+	if !ok || number != math.Trunc(number) || math.IsInf(number, 0) {
+		return nil, fmt.Errorf("expected an integer, got %v", value)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which does not fit in an int64
+	if number < math.MinInt64 || number >= math.MaxInt64 {
+		return nil, fmt.Errorf("the integer %v is out of range", value)
+	}
+	return int(number), nil
+}
+
+func coerceFloat(value interface{}) (interface{}, error) {
+	if number, ok := value.(json.Number); ok {
+		value = number.String()
+	}
+	if text, ok := value.(string); ok {
+		number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		return number, nil
+	}
+
+	number, ok := toFloat(value)
+	if !ok {
+		return nil, fmt.Errorf("expected a number, got %v", value)
+	}
+	return number, nil
+}
+
+func coerceString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	if _, ok := toFloat(value); ok {
+		return fmt.Sprintf("%v", value), nil
+	}
+	return nil, fmt.Errorf("expected a string, got %T", value)
+}
+
+func coerceBoolean(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		flag, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", v)
+		}
+		return flag, nil
+	}
+	return nil, fmt.Errorf("expected a boolean, got %v", value)
+}
+
+func coerceID(value interface{}) (interface{}, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+
+	number, err := coerceInt(value)
+	if err != nil {
+		return nil, fmt.Errorf("expected a string or an integer, got %v", value)
+	}
+	return strconv.Itoa(number.(int)), nil
+}
+
+// toFloat converts the numeric kinds to float64
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
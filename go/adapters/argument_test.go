@@ -0,0 +1,105 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGetParameters_Coercion(t *testing.T) {
+	tests := []struct {
+		name        string
+		declaration string
+		value       interface{}
+		expected    interface{}
+		expectError bool
+	}{
+		{"int a partir de float", "Int", 42.0, 42, false},
+		{"int a partir de texto", "Int", " 42 ", 42, false},
+		{"int com decimais", "Int", 4.2, nil, true},
+		{"int64 acima de 2^53", "Int", int64(9007199254740993), 9007199254740993, false},
+		{"texto acima de 2^53", "Int", "9007199254740993", 9007199254740993, false},
+		{"json.Number acima de 2^53", "Int", json.Number("9007199254740993"), 9007199254740993, false},
+		{"json.Number com expoente", "Int", json.Number("1e3"), 1000, false},
+		{"json.Number com decimais", "Int", json.Number("4.2"), nil, true},
+		{"uint64 fora do intervalo", "Int", uint64(1 << 63), nil, true},
+		{"float fora do intervalo", "Int", 1e30, nil, true},
+		{"float negativo fora do intervalo", "Int", -1e19, nil, true},
+		{"json.Number fora do intervalo", "Int", json.Number("9.3e18"), nil, true},
+		{"id a partir de int64", "ID", int64(9007199254740993), "9007199254740993", false},
+		{"float a partir de json.Number", "Float", json.Number("1.5"), 1.5, false},
+		{"string a partir de json.Number", "String", json.Number("10"), "10", false},
+		{"int inválido", "Int", "abc", nil, true},
+		{"float a partir de int", "Float", 3, 3.0, false},
+		{"float a partir de texto", "Float", "1.5", 1.5, false},
+		{"string a partir de número", "String", 10, "10", false},
+		{"string a partir de objeto", "String", map[string]interface{}{}, nil, true},
+		{"boolean a partir de texto", "Boolean", "true", true, false},
+		{"boolean inválido", "Boolean", 1, nil, true},
+		{"id a partir de int", "ID", 7, "7", false},
+		{"id a partir de texto", "ID", "abc-1", "abc-1", false},
+		{"lista de ints", "[Int]", []interface{}{1.0, "2"}, []interface{}{1, 2}, false},
+		{"valor único como lista", "[String]", "a", []interface{}{"a"}, false},
+		{"item nulo em lista obrigatória", "[Int!]", []interface{}{1, nil}, nil, true},
+		{"opcional ausente", "Int", nil, nil, false},
+		{"obrigatório ausente", "Int!", nil, nil, true},
+		{"lista obrigatória ausente", "[Int]!", nil, nil, true},
+		{"tipo desconhecido mantém o valor", "Date", "2025-01-01", "2025-01-01", false},
+		{"tipo em minúsculas", "int", 10, 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{}
+			if tt.value != nil {
+				args["valor"] = tt.value
+			}
+
+			params, err := getParameters(map[string]interface{}{"valor": tt.declaration}, args)
+			if tt.expectError {
+				var argErr *ArgumentError
+				if !errors.As(err, &argErr) || argErr.Name != "valor" {
+					t.Fatalf("getParameters() erro = %v, esperado *ArgumentError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getParameters() erro = %v", err)
+			}
+
+			if !reflect.DeepEqual(params[0].Value, tt.expected) {
+				t.Errorf("valor = %#v, esperado %#v", params[0].Value, tt.expected)
+			}
+			if params[0].Type != tt.declaration {
+				t.Errorf("tipo = %v, esperado %v", params[0].Type, tt.declaration)
+			}
+		})
+	}
+}
+
+func TestGetParameters_InvalidDeclaration(t *testing.T) {
+	for _, declaration := range []interface{}{1, "[Int", "Int Int", ""} {
+		if _, err := getParameters(map[string]interface{}{"valor": declaration}, nil); err == nil {
+			t.Errorf("getParameters(%v) esperado erro", declaration)
+		}
+	}
+
+	if _, err := New("sql", Settings{Raw: map[string]interface{}{"attr": map[string]interface{}{"id": "[Int"}}}); err == nil {
+		t.Errorf("New() esperado erro para tipo inválido em attr")
+	}
+}
+
+func TestRedisAdapter_RequiredArgument(t *testing.T) {
+	adapter, err := NewRedisAdapter("localhost:6379", "", "CVN_{codigoConvenio}", map[string]interface{}{
+		"codigoConvenio": "Int!",
+	})
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+
+	_, err = adapter.GetParameters(map[string]interface{}{})
+	if err == nil || err.Error() != "invalid argument codigoConvenio (Int!): a value is required" {
+		t.Errorf("GetParameters() erro = %v", err)
+	}
+}
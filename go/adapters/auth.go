@@ -0,0 +1,214 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/raywall/cloud-service-pack/go/authenticator"
+	"github.com/raywall/cloud-service-pack/go/authenticator/handlers"
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+// callerTokenKey is the context key of the credential received from the caller
+type callerTokenKey struct{}
+
+// WithCallerToken returns a copy of the context carrying the credential sent by
+// the caller of the GraphQL API (e.g. "Bearer eyJ..."), which the connectors
+// with a forward auth profile send to their upstreams
+func WithCallerToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, callerTokenKey{}, token)
+}
+
+// CallerToken returns the credential of the caller carried by the context
+func CallerToken(ctx context.Context) string {
+	token, _ := ctx.Value(callerTokenKey{}).(string)
+	return token
+}
+
+// ForwardsCallerToken reports whether the adapter sends the credential of the
+// caller to its upstream. Its results depend on the caller, so they must not be
+// cached or shared between concurrent calls.
+func ForwardsCallerToken(adapter Adapter) bool {
+	forwarder, ok := adapter.(interface{ forwardsCallerToken() bool })
+	return ok && forwarder.forwardsCallerToken()
+}
+
+// requestAuth adds the credentials of an auth profile to the upstream requests
+type requestAuth interface {
+	authorize(ctx context.Context, req *http.Request) error
+}
+
+// stsAuth sends the token of the connector's own STS client, kept updated by
+// the refresh loop of its authenticator until the adapter is closed
+type stsAuth struct {
+	authenticator *authenticator.Authenticator
+	token         string
+}
+
+func (a *stsAuth) authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.authenticator.GetToken()
+	if err != nil {
+		return fmt.Errorf("failed to get the STS token: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Close stops the token refresh loop
+func (a *stsAuth) Close() error {
+	a.authenticator.Stop()
+	return nil
+}
+
+// apiKeyAuth sends a static key in a header
+type apiKeyAuth struct {
+	header string
+	key    string
+}
+
+func (a *apiKeyAuth) authorize(ctx context.Context, req *http.Request) error {
+	req.Header.Set(a.header, a.key)
+	return nil
+}
+
+// basicAuth sends the username and password of the HTTP basic authentication
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (a *basicAuth) authorize(ctx context.Context, req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+// forwardAuth sends the credential received from the caller unchanged. Calls
+// without a caller credential are rejected before reaching the upstream.
+type forwardAuth struct {
+	header string
+}
+
+func (a *forwardAuth) authorize(ctx context.Context, req *http.Request) error {
+	token := CallerToken(ctx)
+	if token == "" {
+		return &StatusError{
+			StatusCode: http.StatusUnauthorized,
+			URL:        req.URL.String(),
+			Message:    "the caller did not send a credential to forward",
+		}
+	}
+	req.Header.Set(a.header, token)
+	return nil
+}
+
+// newRequestAuth creates the authentication of the profile. The sts profiles
+// start their own token refresh loop, so that every connector keeps the token
+// of its issuer and audience.
+func newRequestAuth(profile types.AuthProfile) (requestAuth, error) {
+	switch strings.ToLower(profile.Type) {
+	case types.AuthSTS:
+		service := profile.TokenService
+		if service.TokenAuthorizationURL == "" {
+			return nil, fmt.Errorf("the sts auth profile requires the token authorization url")
+		}
+
+		auth := &stsAuth{}
+		auth.authenticator = authenticator.NewAuthenticator(
+			handlers.NewSTSAuthHandler(
+				service.TokenAuthorizationURL,
+				&handlers.Credential{
+					ClientID:     service.Credentials.ClientID,
+					ClientSecret: service.Credentials.ClientSecret,
+				},
+				&handlers.Options{
+					InsecureSkipVerify: service.InsecureSkipVerify,
+				})).
+			WithController(&auth.token)
+
+		if err := auth.authenticator.Start(); err != nil {
+			auth.authenticator.Stop()
+			return nil, fmt.Errorf("failed to get the STS token: %v", err)
+		}
+		return auth, nil
+
+	case types.AuthAPIKey:
+		if profile.Key == "" {
+			return nil, fmt.Errorf("the apikey auth profile requires the key")
+		}
+		if profile.Header == "" {
+			profile.Header = "X-API-Key"
+		}
+		return &apiKeyAuth{header: profile.Header, key: profile.Key}, nil
+
+	case types.AuthBasic:
+		if profile.Username == "" {
+			return nil, fmt.Errorf("the basic auth profile requires the username")
+		}
+		return &basicAuth{username: profile.Username, password: profile.Password}, nil
+
+	case types.AuthForward:
+		if profile.Header == "" {
+			profile.Header = "Authorization"
+		}
+		return &forwardAuth{header: profile.Header}, nil
+	}
+	return nil, fmt.Errorf("unsupported auth profile type: %s", profile.Type)
+}
+
+// WithAuthProfile authenticates the requests with the auth profile instead of
+// the access token of the GraphQL API
+func (r *restAdapter) WithAuthProfile(profile types.AuthProfile) (RestAdapter, error) {
+	r.auth = false
+
+	if strings.ToLower(profile.Type) == types.AuthSigV4 {
+		return r.WithSigV4(SigV4Options{
+			Region:          profile.Region,
+			Service:         profile.Service,
+			AccessKeyId:     profile.AccessKeyId,
+			SecretAccessKey: profile.SecretAccessKey,
+		})
+	}
+
+	authorizer, err := newRequestAuth(profile)
+	if err != nil {
+		return nil, err
+	}
+	r.authorizer = authorizer
+	return r, nil
+}
+
+// Close stops the token refresh loop of an sts auth profile
+func (r *restAdapter) Close() error {
+	if closer, ok := r.authorizer.(Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (r *restAdapter) forwardsCallerToken() bool {
+	_, ok := r.authorizer.(*forwardAuth)
+	return ok
+}
+
+// authProfileSetting reads the "authProfile" setting, which references one of
+// the auth profiles of the GraphQL API configuration and replaces the "auth" setting
+func authProfileSetting(settings Settings, auth string) (*types.AuthProfile, error) {
+	name, err := settings.String("authProfile")
+	if err != nil || name == "" {
+		return nil, err
+	}
+	if auth != "" {
+		return nil, settings.invalid("authProfile", "cannot be combined with the auth setting")
+	}
+	if settings.Config == nil {
+		return nil, settings.invalid("authProfile", "the auth profile %s does not exist", name)
+	}
+
+	profile, err := settings.Config.GetAuthProfile(name)
+	if err != nil {
+		return nil, settings.invalid("authProfile", "%v", err)
+	}
+	return &profile, nil
+}
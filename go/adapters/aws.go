@@ -0,0 +1,70 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// AWSOptions contains the region, endpoint and credential settings shared by
+// the adapters of AWS services
+type AWSOptions struct {
+	// Region is the AWS region of the service. When empty, the region of the
+	// environment (e.g. AWS_REGION) is used.
+	Region string `json:"region"`
+
+	// Endpoint overrides the service endpoint (e.g. "http://localhost:4566" for LocalStack)
+	Endpoint string `json:"endpoint"`
+
+	// AccessKeyId, SecretAccessKey and SessionToken are optional static
+	// credentials. When empty, the default credential chain is used, which
+	// covers environment variables, shared profiles and the IAM roles of
+	// Lambda, ECS and EC2.
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken"`
+
+	// RoleArn is an optional role assumed with the credentials above, along
+	// with its external id and session name
+	RoleArn         string `json:"roleArn"`
+	ExternalId      string `json:"externalId"`
+	RoleSessionName string `json:"roleSessionName"`
+}
+
+// loadAWSConfig loads the AWS config described by the options
+func loadAWSConfig(ctx context.Context, options AWSOptions) (aws.Config, error) {
+	loaders := []func(*config.LoadOptions) error{config.WithRegion(options.Region)}
+	if options.AccessKeyId != "" {
+		loaders = append(loaders, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(options.AccessKeyId, options.SecretAccessKey, options.SessionToken),
+		))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loaders...)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if options.RoleArn != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), options.RoleArn, func(o *stscreds.AssumeRoleOptions) {
+			if options.ExternalId != "" {
+				o.ExternalID = aws.String(options.ExternalId)
+			}
+			if options.RoleSessionName != "" {
+				o.RoleSessionName = options.RoleSessionName
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	// the endpoint is applied after the role, since STS is not served by it
+	if options.Endpoint != "" {
+		cfg.BaseEndpoint = aws.String(options.Endpoint)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,108 @@
+package adapters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadAWSConfig_AssumeRole(t *testing.T) {
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("Action") != "AssumeRole" || r.Form.Get("RoleArn") != "arn:aws:iam::123456789012:role/leitura" || r.Form.Get("ExternalId") != "consignado" {
+			t.Errorf("requisição STS = %v", r.Form)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKID/") {
+			t.Errorf("Authorization = %q, esperado credenciais estáticas", r.Header.Get("Authorization"))
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(`<AssumeRoleResponse><AssumeRoleResult><Credentials>
+			<AccessKeyId>ASIAROLE</AccessKeyId>
+			<SecretAccessKey>SECRET</SecretAccessKey>
+			<SessionToken>TOKEN</SessionToken>
+			<Expiration>2099-01-01T00:00:00Z</Expiration>
+		</Credentials></AssumeRoleResult></AssumeRoleResponse>`))
+	}))
+	defer sts.Close()
+	t.Setenv("AWS_ENDPOINT_URL_STS", sts.URL)
+
+	cfg, err := loadAWSConfig(context.Background(), AWSOptions{
+		Region:          "us-east-1",
+		Endpoint:        "http://localhost:4566",
+		AccessKeyId:     "AKID",
+		SecretAccessKey: "SECRET",
+		RoleArn:         "arn:aws:iam::123456789012:role/leitura",
+		ExternalId:      "consignado",
+	})
+	if err != nil {
+		t.Fatalf("loadAWSConfig() erro = %v", err)
+	}
+
+	credentials, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("Retrieve() erro = %v", err)
+	}
+	if credentials.AccessKeyID != "ASIAROLE" || credentials.SessionToken != "TOKEN" {
+		t.Errorf("credenciais = %+v, esperado as credenciais do papel assumido", credentials)
+	}
+	if cfg.BaseEndpoint == nil || *cfg.BaseEndpoint != "http://localhost:4566" {
+		t.Errorf("BaseEndpoint = %v", cfg.BaseEndpoint)
+	}
+}
+
+func TestAWSAdapters_DefaultCredentialChain(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "ENVKEY")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "ENVSECRET")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=ENVKEY/") {
+			t.Errorf("Authorization = %q, esperado credenciais do ambiente", r.Header.Get("Authorization"))
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.GetItem":
+			w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+			w.Write([]byte(`{"Item": {"id": {"S": "42"}, "nome": {"S": "INSS"}}}`))
+		default:
+			// o bucket deve ser informado no caminho quando o endpoint é sobrescrito
+			if r.URL.Path != "/convenios/42.json" {
+				t.Errorf("path = %s, esperado /convenios/42.json", r.URL.Path)
+			}
+			w.Write([]byte(`{"codigo": 42}`))
+		}
+	}))
+	defer server.Close()
+
+	args := []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 42}}
+	for _, tt := range []struct {
+		adapter  string
+		raw      map[string]interface{}
+		pattern  string
+		expected interface{}
+	}{
+		{"s3", map[string]interface{}{"bucket": "convenios", "key": "{codigo}.json"}, "", map[string]interface{}{"codigo": 42.0}},
+		{"dynamodb", map[string]interface{}{"table": "convenios"}, "{codigo}", map[string]interface{}{"id": "42", "nome": "INSS"}},
+	} {
+		t.Run(tt.adapter, func(t *testing.T) {
+			tt.raw["region"] = "us-east-1"
+			tt.raw["endpoint"] = server.URL
+
+			adapter, err := New(tt.adapter, Settings{KeyPattern: tt.pattern, Raw: tt.raw})
+			if err != nil {
+				t.Fatalf("New() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), args)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GetData() = %#v, esperado %#v", result, tt.expected)
+			}
+		})
+	}
+}
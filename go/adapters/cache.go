@@ -0,0 +1,335 @@
+package adapters
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CacheStore is a second level cache shared by every instance of the API
+// (e.g. Redis), so that a Lambda cold start does not reach the data source
+type CacheStore interface {
+	// Get returns the value stored in the key and whether it exists
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value in the key until the ttl expires
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the keys from the store
+	Delete(ctx context.Context, keys ...string) error
+
+	// Clear removes every key of the store
+	Clear(ctx context.Context) error
+}
+
+// CachePolicy contains the settings of the read-through cache of an adapter
+type CachePolicy struct {
+	// TTL is how long a result is kept in the cache
+	TTL time.Duration
+
+	// NegativeTTL is how long an empty (nil) result is kept in the cache. Empty
+	// results are not cached when it is zero.
+	NegativeTTL time.Duration
+
+	// MaxEntries limits the number of results kept in memory, evicting the least
+	// recently used ones (1000 by default)
+	MaxEntries int
+
+	// Store is the optional second level cache
+	Store CacheStore
+}
+
+// CachedAdapter is an adapter whose results are cached by the resolved arguments
+type CachedAdapter interface {
+	Adapter
+
+	// Invalidate removes the result cached for the arguments
+	Invalidate(ctx context.Context, args []AdapterAttribute) error
+
+	// Purge removes every cached result
+	Purge(ctx context.Context) error
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// storeEntry is the content kept in the second level store. The expiration goes
+// with the value, so that the in-memory copy does not outlive the stored one.
+type storeEntry struct {
+	Value   interface{} `json:"value"`
+	Expires time.Time   `json:"expires"`
+}
+
+type cachedAdapter struct {
+	Adapter
+	policy CachePolicy
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+// NewCachedAdapter wraps the adapter with a read-through cache. The results are
+// kept in an in-memory LRU and, when informed, in the second level store.
+// Errors are never cached, and failures of the store are treated as misses.
+func NewCachedAdapter(adapter Adapter, policy CachePolicy) CachedAdapter {
+	if policy.MaxEntries <= 0 {
+		policy.MaxEntries = 1000
+	}
+
+	return &cachedAdapter{
+		Adapter: adapter,
+		policy:  policy,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+func (c *cachedAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	key, err := cacheKey(args)
+	if err != nil {
+		return c.Adapter.GetData(ctx, args)
+	}
+
+	if value, found := c.get(key); found {
+		return value, nil
+	}
+
+	if c.policy.Store != nil {
+		if content, found, err := c.policy.Store.Get(ctx, key); err == nil && found {
+			var entry storeEntry
+			if err := json.Unmarshal(content, &entry); err == nil {
+				if remaining := entry.Expires.Sub(c.now()); remaining > 0 {
+					c.set(key, entry.Value, remaining)
+					return entry.Value, nil
+				}
+			}
+		}
+	}
+
+	value, err := c.Adapter.GetData(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := c.ttl(value); ttl > 0 {
+		c.set(key, value, ttl)
+
+		if c.policy.Store != nil {
+			entry := storeEntry{Value: value, Expires: c.now().Add(ttl)}
+			if content, err := json.Marshal(entry); err == nil {
+				_ = c.policy.Store.Set(ctx, key, content, ttl)
+			}
+		}
+	}
+	return value, nil
+}
+
+func (c *cachedAdapter) Invalidate(ctx context.Context, args []AdapterAttribute) error {
+	key, err := cacheKey(args)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+	c.mu.Unlock()
+
+	if c.policy.Store != nil {
+		return c.policy.Store.Delete(ctx, key)
+	}
+	return nil
+}
+
+func (c *cachedAdapter) Purge(ctx context.Context) error {
+	c.mu.Lock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.mu.Unlock()
+
+	if c.policy.Store != nil {
+		return c.policy.Store.Clear(ctx)
+	}
+	return nil
+}
+
+// HealthCheck checks the wrapped adapter, bypassing the cache
+func (c *cachedAdapter) HealthCheck(ctx context.Context) error {
+	return CheckHealth(ctx, c.Adapter)
+}
+
+// Close releases the resources of the wrapped adapter and of the second level
+// store, when the store implements Closer
+func (c *cachedAdapter) Close() error {
+	var errs []error
+	if closer, ok := c.policy.Store.(Closer); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the cache store: %w", err))
+		}
+	}
+	if err := CloseAdapter(c.Adapter); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// ttl returns how long the value can be cached
+func (c *cachedAdapter) ttl(value interface{}) time.Duration {
+	if value == nil {
+		return c.policy.NegativeTTL
+	}
+	return c.policy.TTL
+}
+
+// get returns a valid entry of the in-memory cache, marking it as recently used
+func (c *cachedAdapter) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// set stores the value in the in-memory cache, evicting the least recently used entries
+func (c *cachedAdapter) set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, value: value, expires: c.now().Add(ttl)}
+	if element, exists := c.entries[key]; exists {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.policy.MaxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove deletes an element of the in-memory cache. The lock must be held.
+func (c *cachedAdapter) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// cacheKey builds a key from the resolved argument values, regardless of their order
+func cacheKey(args []AdapterAttribute) (string, error) {
+	sorted := make([]AdapterAttribute, len(args))
+	copy(sorted, args)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	values := make([][2]interface{}, 0, len(sorted))
+	for _, arg := range sorted {
+		values = append(values, [2]interface{}{arg.Name, arg.Value})
+	}
+
+	content, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to build the cache key: %v", err)
+	}
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+type redisCacheStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCacheStore creates a second level cache in Redis. Every key is
+// stored with the prefix, which must be unique per connector.
+func NewRedisCacheStore(options RedisOptions, prefix string) (CacheStore, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("the redis cache store requires a key prefix")
+	}
+
+	client, err := newRedisClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisCacheStore{client: client, prefix: prefix}, nil
+}
+
+func (r *redisCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	content, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return content, true, nil
+}
+
+func (r *redisCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, r.prefix+key, value, ttl).Err()
+}
+
+func (r *redisCacheStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, r.prefix+key)
+	}
+	return r.client.Del(ctx, prefixed...).Err()
+}
+
+// Close closes the connections of the Redis client
+func (r *redisCacheStore) Close() error {
+	return r.client.Close()
+}
+
+func (r *redisCacheStore) Clear(ctx context.Context) error {
+	// the keys of a cluster are spread among the masters, which are scanned one by one
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return r.clear(ctx, client)
+		})
+	}
+	return r.clear(ctx, r.client)
+}
+
+// clear deletes the keys with the store prefix found in a node
+func (r *redisCacheStore) clear(ctx context.Context, client redis.Cmdable) error {
+	iter := client.Scan(ctx, 0, r.prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,232 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// Mock de adapter que responde conforme o argumento "id"
+type sourceAdapter struct {
+	calls int32
+	fail  bool
+}
+
+func (s *sourceAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.fail {
+		return nil, errors.New("falha na origem")
+	}
+
+	id := fmt.Sprintf("%v", args[0].Value)
+	if id == "vazio" {
+		return nil, nil
+	}
+	return map[string]interface{}{"id": id}, nil
+}
+
+func (s *sourceAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return nil, nil
+}
+
+func idArgs(id string) []AdapterAttribute {
+	return []AdapterAttribute{{Name: "id", Type: "String", Value: id}}
+}
+
+func TestCachedAdapter_TTL(t *testing.T) {
+	source := &sourceAdapter{}
+	cached := NewCachedAdapter(source, CachePolicy{TTL: time.Minute}).(*cachedAdapter)
+
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := cached.GetData(context.Background(), idArgs("1")); err != nil {
+			t.Fatalf("GetData() erro = %v", err)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("chamadas à origem = %d, esperado 1", source.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	cached.GetData(context.Background(), idArgs("1"))
+	if source.calls != 2 {
+		t.Errorf("chamadas à origem após expirar = %d, esperado 2", source.calls)
+	}
+}
+
+func TestCachedAdapter_LRU(t *testing.T) {
+	source := &sourceAdapter{}
+	cached := NewCachedAdapter(source, CachePolicy{TTL: time.Minute, MaxEntries: 2})
+	ctx := context.Background()
+
+	cached.GetData(ctx, idArgs("1"))
+	cached.GetData(ctx, idArgs("2"))
+	cached.GetData(ctx, idArgs("1")) // "1" passa a ser o mais recente
+	cached.GetData(ctx, idArgs("3")) // remove "2"
+
+	cached.GetData(ctx, idArgs("1"))
+	if source.calls != 3 {
+		t.Errorf("chamadas à origem = %d, esperado 3", source.calls)
+	}
+
+	cached.GetData(ctx, idArgs("2"))
+	if source.calls != 4 {
+		t.Errorf("chamadas à origem = %d, esperado 4 (entrada removida)", source.calls)
+	}
+}
+
+func TestCachedAdapter_NegativeAndErrors(t *testing.T) {
+	ctx := context.Background()
+
+	source := &sourceAdapter{}
+	cached := NewCachedAdapter(source, CachePolicy{TTL: time.Minute})
+	cached.GetData(ctx, idArgs("vazio"))
+	cached.GetData(ctx, idArgs("vazio"))
+	if source.calls != 2 {
+		t.Errorf("resultado vazio sem negativeTtl não deveria ser armazenado, chamadas = %d", source.calls)
+	}
+
+	source = &sourceAdapter{}
+	cached = NewCachedAdapter(source, CachePolicy{TTL: time.Minute, NegativeTTL: time.Minute})
+	cached.GetData(ctx, idArgs("vazio"))
+	if result, _ := cached.GetData(ctx, idArgs("vazio")); result != nil || source.calls != 1 {
+		t.Errorf("GetData() = %v com %d chamadas, esperado nil com 1 chamada", result, source.calls)
+	}
+
+	source = &sourceAdapter{fail: true}
+	cached = NewCachedAdapter(source, CachePolicy{TTL: time.Minute})
+	cached.GetData(ctx, idArgs("1"))
+	if _, err := cached.GetData(ctx, idArgs("1")); err == nil || source.calls != 2 {
+		t.Errorf("erros não deveriam ser armazenados, chamadas = %d", source.calls)
+	}
+}
+
+func TestCachedAdapter_RedisStoreAndInvalidate(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis erro = %v", err)
+	}
+	defer mr.Close()
+
+	store, err := NewRedisCacheStore(RedisOptions{Addrs: []string{mr.Addr()}}, "cache:convenio:")
+	if err != nil {
+		t.Fatalf("NewRedisCacheStore() erro = %v", err)
+	}
+
+	ctx := context.Background()
+	source := &sourceAdapter{}
+	first := NewCachedAdapter(source, CachePolicy{TTL: time.Minute, Store: store})
+	first.GetData(ctx, idArgs("1"))
+
+	// uma nova instância (ex.: outra Lambda) lê o segundo nível
+	second := NewCachedAdapter(source, CachePolicy{TTL: time.Minute, Store: store})
+	result, err := second.GetData(ctx, idArgs("1"))
+	if err != nil || source.calls != 1 {
+		t.Fatalf("GetData() erro = %v, chamadas = %d, esperado 1", err, source.calls)
+	}
+	if result.(map[string]interface{})["id"] != "1" {
+		t.Errorf("GetData() = %v, esperado id 1", result)
+	}
+
+	if err := second.Invalidate(ctx, idArgs("1")); err != nil {
+		t.Fatalf("Invalidate() erro = %v", err)
+	}
+	second.GetData(ctx, idArgs("1"))
+	if source.calls != 2 {
+		t.Errorf("chamadas à origem após invalidar = %d, esperado 2", source.calls)
+	}
+
+	if err := second.Purge(ctx); err != nil {
+		t.Fatalf("Purge() erro = %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("chaves após Purge() = %v, esperado nenhuma", keys)
+	}
+}
+
+func TestCachedAdapter_StoreRemainingTTL(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis erro = %v", err)
+	}
+	defer mr.Close()
+
+	store, err := NewRedisCacheStore(RedisOptions{Addrs: []string{mr.Addr()}}, "cache:convenio:")
+	if err != nil {
+		t.Fatalf("NewRedisCacheStore() erro = %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	source := &sourceAdapter{}
+
+	first := NewCachedAdapter(source, CachePolicy{TTL: time.Minute, Store: store}).(*cachedAdapter)
+	first.now = func() time.Time { return now }
+	first.GetData(ctx, idArgs("1"))
+
+	// a cópia em memória lida do segundo nível expira junto com ele
+	now = now.Add(40 * time.Second)
+	second := NewCachedAdapter(source, CachePolicy{TTL: time.Minute, Store: store}).(*cachedAdapter)
+	second.now = func() time.Time { return now }
+	second.GetData(ctx, idArgs("1"))
+	if source.calls != 1 {
+		t.Fatalf("chamadas à origem = %d, esperado 1", source.calls)
+	}
+
+	now = now.Add(30 * time.Second)
+	second.GetData(ctx, idArgs("1"))
+	if source.calls != 2 {
+		t.Errorf("chamadas à origem após o ttl original = %d, esperado 2", source.calls)
+	}
+}
+
+func TestCachedAdapter_NegativeRedis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis erro = %v", err)
+	}
+	defer mr.Close()
+
+	adapter, err := NewRedisAdapter(mr.Addr(), "", "convenio:{id}", map[string]interface{}{"id": "String"})
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+	cached := NewCachedAdapter(adapter, CachePolicy{TTL: time.Hour, NegativeTTL: time.Minute}).(*cachedAdapter)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	// a chave inexistente fica armazenada como nula durante o negativeTtl
+	ctx := context.Background()
+	if result, err := cached.GetData(ctx, idArgs("42")); err != nil || result != nil {
+		t.Fatalf("GetData() = %v, erro = %v, esperado nil", result, err)
+	}
+	mr.Set("convenio:42", `{"codigo": 42}`)
+	if result, _ := cached.GetData(ctx, idArgs("42")); result != nil {
+		t.Errorf("GetData() = %v, esperado nil armazenado", result)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if result, _ := cached.GetData(ctx, idArgs("42")); result == nil {
+		t.Errorf("GetData() = nil, esperado o valor após o negativeTtl")
+	}
+}
+
+func TestCacheKey_IgnoresOrder(t *testing.T) {
+	a, _ := cacheKey([]AdapterAttribute{{Name: "a", Value: 1}, {Name: "b", Value: "x"}})
+	b, _ := cacheKey([]AdapterAttribute{{Name: "b", Value: "x"}, {Name: "a", Value: 1}})
+	c, _ := cacheKey([]AdapterAttribute{{Name: "a", Value: 2}, {Name: "b", Value: "x"}})
+
+	if a != b {
+		t.Errorf("cacheKey() deveria ignorar a ordem dos argumentos")
+	}
+	if a == c {
+		t.Errorf("cacheKey() deveria diferenciar os valores dos argumentos")
+	}
+}
@@ -0,0 +1,17 @@
+package adapters
+
+// Closer is implemented by the adapters that hold resources until the API is
+// shut down (e.g. connection pools or the token refresh loop of an sts auth profile)
+type Closer interface {
+	Close() error
+}
+
+// CloseAdapter releases the resources of the adapter. Adapters that do not
+// implement Closer have nothing to release.
+func CloseAdapter(adapter Adapter) error {
+	closer, ok := adapter.(Closer)
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// Adapter que conta as liberações de recursos
+type closingAdapter struct {
+	mockAdapter
+	closes int
+}
+
+func (c *closingAdapter) Close() error {
+	c.closes++
+	return nil
+}
+
+func TestCloseAdapter_Wrappers(t *testing.T) {
+	inner := &closingAdapter{}
+
+	mapped, err := NewMappingAdapter(NewResilientAdapter(inner, ResiliencePolicy{MaxAttempts: 1}), MappingPolicy{Fields: map[string]MappingField{"codigo": {}}})
+	if err != nil {
+		t.Fatalf("NewMappingAdapter() erro = %v", err)
+	}
+	adapter := NewCachedAdapter(NewCoalescingAdapter(mapped), CachePolicy{TTL: time.Minute})
+
+	if err := CloseAdapter(adapter); err != nil {
+		t.Errorf("CloseAdapter() erro = %v", err)
+	}
+	if inner.closes != 1 {
+		t.Errorf("liberações = %d, esperado 1", inner.closes)
+	}
+
+	if err := CloseAdapter(&mockAdapter{}); err != nil {
+		t.Errorf("CloseAdapter() erro = %v, esperado nil sem recursos", err)
+	}
+}
+
+func TestCloseAdapter_Redis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run() erro = %v", err)
+	}
+	defer mr.Close()
+
+	adapter, err := NewRedisAdapter(mr.Addr(), "", "convenio:{codigo}", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+	store, err := NewRedisCacheStore(RedisOptions{Addrs: []string{mr.Addr()}}, "cache:convenio:")
+	if err != nil {
+		t.Fatalf("NewRedisCacheStore() erro = %v", err)
+	}
+
+	cached := NewCachedAdapter(adapter, CachePolicy{TTL: time.Minute, Store: store})
+	if err := CloseAdapter(cached); err != nil {
+		t.Fatalf("CloseAdapter() erro = %v", err)
+	}
+
+	// os clientes fechados não aceitam novos comandos
+	if err := CheckHealth(context.Background(), adapter); err == nil {
+		t.Errorf("CheckHealth() esperado erro após o fechamento")
+	}
+	if _, _, err := store.Get(context.Background(), "1"); err == nil {
+		t.Errorf("Get() esperado erro após o fechamento")
+	}
+}
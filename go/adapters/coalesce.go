@@ -0,0 +1,117 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultFlightTimeout limits a shared call, which no longer follows the
+// deadline of the caller that started it
+const defaultFlightTimeout = 30 * time.Second
+
+// CoalescingAdapter is an adapter that collapses identical concurrent calls
+type CoalescingAdapter interface {
+	Adapter
+
+	// Deduplicated returns how many calls were answered by a call already in flight
+	Deduplicated() int64
+}
+
+// flight is an upstream call shared by the callers with the same arguments
+type flight struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+type coalescingAdapter struct {
+	Adapter
+
+	mu           sync.Mutex
+	flights      map[string]*flight
+	deduplicated int64
+	timeout      time.Duration
+}
+
+// NewCoalescingAdapter wraps the adapter so that concurrent calls with the same
+// resolved arguments share a single upstream call and its result. The shared
+// call keeps the values of the first caller's context, but not its cancellation
+// or deadline, so a client that goes away does not fail the others; every
+// caller stops waiting when its own context is done.
+func NewCoalescingAdapter(adapter Adapter) CoalescingAdapter {
+	return NewCoalescingAdapterWithTimeout(adapter, defaultFlightTimeout)
+}
+
+// NewCoalescingAdapterWithTimeout works like NewCoalescingAdapter, limiting the
+// shared calls to the timeout informed (30 seconds when not positive)
+func NewCoalescingAdapterWithTimeout(adapter Adapter, timeout time.Duration) CoalescingAdapter {
+	if timeout <= 0 {
+		timeout = defaultFlightTimeout
+	}
+	return &coalescingAdapter{
+		Adapter: adapter,
+		flights: make(map[string]*flight),
+		timeout: timeout,
+	}
+}
+
+func (c *coalescingAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	key, err := cacheKey(args)
+	if err != nil {
+		return c.Adapter.GetData(ctx, args)
+	}
+
+	c.mu.Lock()
+	current, exists := c.flights[key]
+	if exists {
+		atomic.AddInt64(&c.deduplicated, 1)
+	} else {
+		current = &flight{done: make(chan struct{})}
+		c.flights[key] = current
+		go c.fly(context.WithoutCancel(ctx), key, current, args)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-current.done:
+		return current.value, current.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fly performs the shared call. A panic of the adapter is reported to every
+// caller as an error, instead of leaving them without a result.
+func (c *coalescingAdapter) fly(ctx context.Context, key string, current *flight, args []AdapterAttribute) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			current.value, current.err = nil, fmt.Errorf("the adapter panicked: %v", recovered)
+		}
+		cancel()
+
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+		close(current.done)
+	}()
+
+	current.value, current.err = c.Adapter.GetData(ctx, args)
+}
+
+func (c *coalescingAdapter) Deduplicated() int64 {
+	return atomic.LoadInt64(&c.deduplicated)
+}
+
+// HealthCheck checks the wrapped adapter, without sharing the probe with other calls
+func (c *coalescingAdapter) HealthCheck(ctx context.Context) error {
+	return CheckHealth(ctx, c.Adapter)
+}
+
+// Close releases the resources of the wrapped adapter
+func (c *coalescingAdapter) Close() error {
+	return CloseAdapter(c.Adapter)
+}
@@ -0,0 +1,165 @@
+package adapters
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Mock de adapter que só responde após ser liberado
+type blockingAdapter struct {
+	calls   int32
+	release chan struct{}
+}
+
+func (b *blockingAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	atomic.AddInt32(&b.calls, 1)
+	<-b.release
+	return args[0].Value, nil
+}
+
+func (b *blockingAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return nil, nil
+}
+
+func TestCoalescingAdapter_SharesInFlightCalls(t *testing.T) {
+	source := &blockingAdapter{release: make(chan struct{})}
+	adapter := NewCoalescingAdapter(source)
+
+	const callers = 10
+	var (
+		wg      sync.WaitGroup
+		results = make([]interface{}, callers)
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = adapter.GetData(context.Background(), idArgs("42"))
+		}(i)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for adapter.Deduplicated() < callers-1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(source.release)
+	wg.Wait()
+
+	if source.calls != 1 {
+		t.Errorf("chamadas à origem = %d, esperado 1", source.calls)
+	}
+	if adapter.Deduplicated() != callers-1 {
+		t.Errorf("Deduplicated() = %d, esperado %d", adapter.Deduplicated(), callers-1)
+	}
+	for i, result := range results {
+		if result != "42" {
+			t.Errorf("resultado %d = %v, esperado 42", i, result)
+		}
+	}
+}
+
+func TestCoalescingAdapter_DistinctArguments(t *testing.T) {
+	source := &blockingAdapter{release: make(chan struct{})}
+	close(source.release)
+	adapter := NewCoalescingAdapter(source)
+
+	adapter.GetData(context.Background(), idArgs("1"))
+	adapter.GetData(context.Background(), idArgs("1"))
+	adapter.GetData(context.Background(), idArgs("2"))
+
+	if source.calls != 3 || adapter.Deduplicated() != 0 {
+		t.Errorf("chamadas = %d, deduplicadas = %d, esperado 3 e 0", source.calls, adapter.Deduplicated())
+	}
+}
+
+func TestCoalescingAdapter_WaiterContext(t *testing.T) {
+	source := &blockingAdapter{release: make(chan struct{})}
+	defer close(source.release)
+	adapter := NewCoalescingAdapter(source)
+
+	go adapter.GetData(context.Background(), idArgs("1"))
+	for atomic.LoadInt32(&source.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := adapter.GetData(ctx, idArgs("1")); err != context.DeadlineExceeded {
+		t.Errorf("GetData() erro = %v, esperado context.DeadlineExceeded", err)
+	}
+}
+
+func TestCoalescingAdapter_LeaderCanceled(t *testing.T) {
+	source := &blockingAdapter{release: make(chan struct{})}
+	adapter := NewCoalescingAdapter(source)
+
+	// o primeiro cliente desiste da chamada compartilhada
+	leader, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := adapter.GetData(leader, idArgs("1"))
+		leaderErr <- err
+	}()
+	for atomic.LoadInt32(&source.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	follower := make(chan interface{}, 1)
+	go func() {
+		result, _ := adapter.GetData(context.Background(), idArgs("1"))
+		follower <- result
+	}()
+	for adapter.Deduplicated() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-leaderErr; err != context.Canceled {
+		t.Errorf("GetData() erro = %v, esperado context.Canceled", err)
+	}
+
+	close(source.release)
+	if result := <-follower; result != "1" {
+		t.Errorf("resultado = %v, esperado 1 apesar do cancelamento do primeiro cliente", result)
+	}
+}
+
+// panicAdapter entra em pânico ao ser chamado
+type panicAdapter struct {
+	release chan struct{}
+}
+
+func (p *panicAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	<-p.release
+	panic("falha inesperada")
+}
+
+func (p *panicAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return nil, nil
+}
+
+func TestCoalescingAdapter_Panic(t *testing.T) {
+	source := &panicAdapter{release: make(chan struct{})}
+	adapter := NewCoalescingAdapter(source)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := adapter.GetData(context.Background(), idArgs("1"))
+			errs <- err
+		}()
+	}
+	for adapter.Deduplicated() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(source.release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err == nil {
+			t.Errorf("GetData() esperado erro após o pânico do adapter")
+		}
+	}
+}
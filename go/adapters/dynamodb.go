@@ -3,67 +3,317 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+func init() {
+	Register("dynamodb", newDynamoDBFromSettings)
+}
+
 type DynamoDBAdapter interface {
 	Adapter
+
+	// WithOptions defines the key schema of the table and, optionally, the
+	// query executed against the table or one of its indexes.
+	WithOptions(options DynamoDBOptions) (DynamoDBAdapter, error)
+}
+
+// DynamoDBKey describes a key attribute of the table and the template used
+// to build its value from the arguments
+type DynamoDBKey struct {
+	// Name is the name of the key attribute in the table
+	Name string `json:"name"`
+
+	// Type is the DynamoDB type of the key: S (string), N (number) or B (binary)
+	Type string `json:"type"`
+
+	// Value is the template of the key value (e.g. "CVN#{codigoConvenio}")
+	Value string `json:"value"`
+}
+
+// DynamoDBQuery contains the settings of a Query against the table or an index
+type DynamoDBQuery struct {
+	// Index is the name of the global or local secondary index queried
+	Index string `json:"index"`
+
+	// KeyCondition is the key condition expression (e.g. "gsi1pk = :pk")
+	KeyCondition string `json:"keyCondition"`
+
+	// Filter is an optional filter expression applied to the items read
+	Filter string `json:"filter"`
+
+	// Values maps the expression placeholders (e.g. ":pk") to value templates
+	Values map[string]string `json:"values"`
+
+	// Names maps the expression attribute names (e.g. "#status") to attributes
+	Names map[string]string `json:"names"`
+
+	// Limit is the maximum number of items returned (all pages when zero)
+	Limit int `json:"limit"`
+}
+
+// DynamoDBOptions contains the key schema and the query settings of the adapter
+type DynamoDBOptions struct {
+	PartitionKey DynamoDBKey    `json:"partitionKey"`
+	SortKey      *DynamoDBKey   `json:"sortKey"`
+	Query        *DynamoDBQuery `json:"query"`
+}
+
+// dynamoDBAPI contains the operations of the DynamoDB client used by the adapter
+type dynamoDBAPI interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
 }
 
 type dynamoDBAdapter struct {
-	client *dynamodb.Client
-	table  string
-	attr   map[string]interface{}
-}
-
-func NewDynamoDBAdapter(region, table, accessKeyId, secretAccessKey string) DynamoDBAdapter {
-	cfg, err := config.LoadDefaultConfig(context.Background(),
-		config.WithRegion(region),
-		config.WithCredentialsProvider(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
-			return aws.Credentials{
-				AccessKeyID:     accessKeyId,
-				SecretAccessKey: secretAccessKey,
-			}, nil
-		})),
-	)
+	client     dynamoDBAPI
+	table      string
+	keyPattern string
+	attr       map[string]interface{}
+	options    DynamoDBOptions
+}
+
+// NewDynamoDBAdapter creates an adapter that reads the items of a table. The
+// static credentials of the AWS options are optional, the default credential
+// chain is used without them.
+func NewDynamoDBAdapter(options AWSOptions, table, keyPattern string, attributes map[string]interface{}) (DynamoDBAdapter, error) {
+	if table == "" {
+		return nil, fmt.Errorf("the DynamoDB adapter requires the table name")
+	}
+
+	cfg, err := loadAWSConfig(context.Background(), options)
 	if err != nil {
-		panic(fmt.Errorf("failed to load AWS config: %v", err))
+		return nil, err
 	}
 
 	return &dynamoDBAdapter{
-		client: dynamodb.NewFromConfig(cfg),
-		table:  table,
+		client:     dynamodb.NewFromConfig(cfg),
+		table:      table,
+		keyPattern: keyPattern,
+		attr:       attributes,
+		options: DynamoDBOptions{
+			PartitionKey: DynamoDBKey{Name: "id", Type: "S", Value: keyPattern},
+		},
+	}, nil
+}
+
+// newDynamoDBFromSettings creates a DynamoDB adapter from the connector settings
+func newDynamoDBFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := settings.RequiredString("table")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		awsOptions AWSOptions
+		options    DynamoDBOptions
+	)
+	if err := settings.Decode(&awsOptions); err != nil {
+		return nil, err
+	}
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewDynamoDBAdapter(awsOptions, table, settings.KeyPattern, attributes)
+	if err != nil {
+		return nil, settings.wrap(err)
+	}
+
+	adapter, err = adapter.WithOptions(options)
+	return adapter, settings.wrap(err)
+}
+
+func (d *dynamoDBAdapter) WithOptions(options DynamoDBOptions) (DynamoDBAdapter, error) {
+	if options.Query == nil {
+		if options.PartitionKey.Name == "" {
+			options.PartitionKey.Name = "id"
+		}
+		if options.PartitionKey.Value == "" {
+			options.PartitionKey.Value = d.keyPattern
+		}
+
+		keys := []*DynamoDBKey{&options.PartitionKey}
+		if options.SortKey != nil {
+			keys = append(keys, options.SortKey)
+		}
+
+		for _, key := range keys {
+			key.Type = strings.ToUpper(key.Type)
+			switch key.Type {
+			case "":
+				key.Type = "S"
+			case "S", "N", "B":
+			default:
+				return nil, fmt.Errorf("unsupported type %s for DynamoDB key %s", key.Type, key.Name)
+			}
+
+			if key.Name == "" {
+				return nil, fmt.Errorf("the DynamoDB key name was not informed")
+			}
+			if strings.TrimSpace(key.Value) == "" {
+				return nil, fmt.Errorf("the value of DynamoDB key %s was not informed", key.Name)
+			}
+			if _, err := ParseTemplate(key.Value); err != nil {
+				return nil, fmt.Errorf("invalid value of DynamoDB key %s: %v", key.Name, err)
+			}
+		}
+	} else {
+		if options.Query.KeyCondition == "" {
+			return nil, fmt.Errorf("the DynamoDB query requires a key condition")
+		}
+		for name, value := range options.Query.Values {
+			if _, err := ParseTemplate(value); err != nil {
+				return nil, fmt.Errorf("invalid value of DynamoDB expression %s: %v", name, err)
+			}
+		}
 	}
+
+	d.options = options
+	return d, nil
 }
 
-func (d *dynamoDBAdapter) GetData(args []AdapterAttribute) (interface{}, error) {
-	key := args[0]
+func (d *dynamoDBAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	if d.options.Query != nil {
+		return d.query(ctx, args)
+	}
 
-	result, err := d.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+	key, err := d.buildKey(args)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(d.table),
-		Key: map[string]types.AttributeValue{
-			"id": &types.AttributeValueMemberS{Value: key.Name},
-		},
+		Key:       key,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get item %s from DynamoDB: %v", key, err)
+		return nil, fmt.Errorf("failed to get item %s from DynamoDB: %w", describeKey(key), err)
 	}
+
+	// a missing item is a null value, so that it can be cached like any other result
 	if result.Item == nil {
-		return nil, fmt.Errorf("item %s not found in DynamoDB", key)
+		return nil, nil
 	}
 
 	var data map[string]interface{}
 	if err := attributevalue.UnmarshalMap(result.Item, &data); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal DynamoDB item %s: %v", key, err)
+		return nil, fmt.Errorf("failed to unmarshal DynamoDB item %s: %v", describeKey(key), err)
 	}
 	return data, nil
 }
 
+// query reads every page of the configured query, up to the item limit
+func (d *dynamoDBAdapter) query(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	query := d.options.Query
+
+	values := make(map[string]types.AttributeValue, len(query.Values))
+	for name, tpl := range query.Values {
+		value, err := renderTemplate(tpl, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build DynamoDB expression value %s: %v", name, err)
+		}
+		if values[name], err = attributevalue.Marshal(value); err != nil {
+			return nil, fmt.Errorf("failed to marshal DynamoDB expression value %s: %v", name, err)
+		}
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.table),
+		KeyConditionExpression:    aws.String(query.KeyCondition),
+		ExpressionAttributeValues: values,
+	}
+	if query.Index != "" {
+		input.IndexName = aws.String(query.Index)
+	}
+	if query.Filter != "" {
+		input.FilterExpression = aws.String(query.Filter)
+	}
+	if len(query.Names) > 0 {
+		input.ExpressionAttributeNames = query.Names
+	}
+
+	items := make([]interface{}, 0)
+	paginator := dynamodb.NewQueryPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query DynamoDB table %s: %w", d.table, err)
+		}
+
+		for _, item := range page.Items {
+			var data map[string]interface{}
+			if err := attributevalue.UnmarshalMap(item, &data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal DynamoDB item: %v", err)
+			}
+			items = append(items, data)
+
+			if query.Limit > 0 && len(items) >= query.Limit {
+				return items, nil
+			}
+		}
+	}
+	return items, nil
+}
+
+// buildKey creates the primary key of the item from the arguments
+func (d *dynamoDBAdapter) buildKey(args []AdapterAttribute) (map[string]types.AttributeValue, error) {
+	keys := []DynamoDBKey{d.options.PartitionKey}
+	if d.options.SortKey != nil {
+		keys = append(keys, *d.options.SortKey)
+	}
+
+	item := make(map[string]types.AttributeValue, len(keys))
+	for _, key := range keys {
+		value, err := renderText(key.Value, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the DynamoDB key %s: %v", key.Name, err)
+		}
+
+		switch key.Type {
+		case "N":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("the DynamoDB key %s requires a number, got %q", key.Name, value)
+			}
+			item[key.Name] = &types.AttributeValueMemberN{Value: value}
+		case "B":
+			item[key.Name] = &types.AttributeValueMemberB{Value: []byte(value)}
+		default:
+			item[key.Name] = &types.AttributeValueMemberS{Value: value}
+		}
+	}
+	return item, nil
+}
+
+// describeKey formats the primary key of an item to be used in error messages
+func describeKey(key map[string]types.AttributeValue) string {
+	var values map[string]interface{}
+	if err := attributevalue.UnmarshalMap(key, &values); err != nil {
+		return fmt.Sprintf("%v", key)
+	}
+	return fmt.Sprintf("%v", values)
+}
+
+// HealthCheck describes the table, which fails when it is unreachable or missing
+func (d *dynamoDBAdapter) HealthCheck(ctx context.Context) error {
+	if _, err := d.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(d.table)}); err != nil {
+		return fmt.Errorf("failed to describe DynamoDB table %s: %w", d.table, err)
+	}
+	return nil
+}
+
 func (r *dynamoDBAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
 	return getParameters(r.attr, args)
 }
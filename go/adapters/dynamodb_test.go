@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Mock do cliente DynamoDB
+type mockDynamoDB struct {
+	getInput   *dynamodb.GetItemInput
+	queryInput []*dynamodb.QueryInput
+	item       map[string]types.AttributeValue
+	pages      [][]map[string]types.AttributeValue
+	tables     []string
+}
+
+func (m *mockDynamoDB) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	m.getInput = params
+	return &dynamodb.GetItemOutput{Item: m.item}, nil
+}
+
+func (m *mockDynamoDB) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	page := len(m.queryInput)
+	m.queryInput = append(m.queryInput, params)
+
+	output := &dynamodb.QueryOutput{Items: m.pages[page]}
+	if page < len(m.pages)-1 {
+		output.LastEvaluatedKey = map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: "next"},
+		}
+	}
+	return output, nil
+}
+
+func (m *mockDynamoDB) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	m.tables = append(m.tables, aws.ToString(params.TableName))
+	return &dynamodb.DescribeTableOutput{}, nil
+}
+
+func TestDynamoDBAdapter_GetData_CompositeKey(t *testing.T) {
+	client := &mockDynamoDB{
+		item: map[string]types.AttributeValue{
+			"pk":   &types.AttributeValueMemberS{Value: "CVN#42"},
+			"nome": &types.AttributeValueMemberS{Value: "Convenio 42"},
+		},
+	}
+
+	adapter, err := (&dynamoDBAdapter{client: client, table: "convenios"}).WithOptions(DynamoDBOptions{
+		PartitionKey: DynamoDBKey{Name: "pk", Value: "CVN#{codigoConvenio}"},
+		SortKey:      &DynamoDBKey{Name: "versao", Type: "n", Value: "{versao}"},
+	})
+	if err != nil {
+		t.Fatalf("WithOptions() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 42},
+		{Name: "versao", Type: "Int", Value: 3},
+	})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	expectedKey := map[string]types.AttributeValue{
+		"pk":     &types.AttributeValueMemberS{Value: "CVN#42"},
+		"versao": &types.AttributeValueMemberN{Value: "3"},
+	}
+	if !reflect.DeepEqual(client.getInput.Key, expectedKey) {
+		t.Errorf("key = %#v, esperado %#v", client.getInput.Key, expectedKey)
+	}
+
+	if result.(map[string]interface{})["nome"] != "Convenio 42" {
+		t.Errorf("result = %v, esperado nome Convenio 42", result)
+	}
+
+	// chave numérica com valor não numérico
+	_, err = adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 42},
+		{Name: "versao", Type: "String", Value: "abc"},
+	})
+	if err == nil {
+		t.Fatal("esperado erro para chave numérica inválida")
+	}
+
+	// o item inexistente é um valor nulo, não uma falha
+	client.item = nil
+	result, err = adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 7},
+		{Name: "versao", Type: "Int", Value: 1},
+	})
+	if err != nil || result != nil {
+		t.Errorf("GetData() = %v, erro = %v, esperado nil para item inexistente", result, err)
+	}
+}
+
+func TestDynamoDBAdapter_GetData_Query(t *testing.T) {
+	client := &mockDynamoDB{
+		pages: [][]map[string]types.AttributeValue{
+			{
+				{"id": &types.AttributeValueMemberN{Value: "1"}},
+				{"id": &types.AttributeValueMemberN{Value: "2"}},
+			},
+			{
+				{"id": &types.AttributeValueMemberN{Value: "3"}},
+				{"id": &types.AttributeValueMemberN{Value: "4"}},
+			},
+		},
+	}
+
+	adapter, err := (&dynamoDBAdapter{client: client, table: "convenios"}).WithOptions(DynamoDBOptions{
+		Query: &DynamoDBQuery{
+			Index:        "gsi-produto",
+			KeyCondition: "produto = :produto",
+			Filter:       "#ativo = :ativo",
+			Values:       map[string]string{":produto": "{produto|upper}", ":ativo": "{ativo}"},
+			Names:        map[string]string{"#ativo": "ativo"},
+			Limit:        3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("WithOptions() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "produto", Type: "String", Value: "consignado"},
+		{Name: "ativo", Type: "Boolean", Value: true},
+	})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	items, ok := result.([]interface{})
+	if !ok || len(items) != 3 {
+		t.Fatalf("result = %v, esperado lista com 3 itens", result)
+	}
+
+	input := client.queryInput[0]
+	if aws.ToString(input.IndexName) != "gsi-produto" || aws.ToString(input.FilterExpression) != "#ativo = :ativo" {
+		t.Errorf("query = %+v, esperado índice e filtro configurados", input)
+	}
+
+	expectedValues := map[string]types.AttributeValue{
+		":produto": &types.AttributeValueMemberS{Value: "CONSIGNADO"},
+		":ativo":   &types.AttributeValueMemberBOOL{Value: true},
+	}
+	if !reflect.DeepEqual(input.ExpressionAttributeValues, expectedValues) {
+		t.Errorf("values = %#v, esperado %#v", input.ExpressionAttributeValues, expectedValues)
+	}
+
+	if len(client.queryInput) != 2 {
+		t.Errorf("páginas lidas = %d, esperado 2", len(client.queryInput))
+	}
+}
+
+func TestDynamoDBAdapter_WithOptions_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		options DynamoDBOptions
+	}{
+		{"tipo de chave inválido", DynamoDBOptions{PartitionKey: DynamoDBKey{Name: "pk", Type: "BOOL", Value: "{id}"}}},
+		{"sort key sem nome", DynamoDBOptions{PartitionKey: DynamoDBKey{Name: "pk", Value: "{id}"}, SortKey: &DynamoDBKey{Value: "{sk}"}}},
+		{"partition key sem valor", DynamoDBOptions{PartitionKey: DynamoDBKey{Name: "pk"}}},
+		{"sort key sem valor", DynamoDBOptions{PartitionKey: DynamoDBKey{Name: "pk", Value: "{id}"}, SortKey: &DynamoDBKey{Name: "sk", Value: " "}}},
+		{"query sem condição", DynamoDBOptions{Query: &DynamoDBQuery{Index: "gsi"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (&dynamoDBAdapter{}).WithOptions(tt.options); err == nil {
+				t.Error("esperado erro de configuração")
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+func init() {
+	Register("graphql", newGraphQLFromSettings)
+}
+
+// Policies applied when the remote GraphQL service answers with errors
+const (
+	GraphQLErrorsFail    = "fail"
+	GraphQLErrorsPartial = "partial"
+)
+
+type GraphQLAdapter interface {
+	Adapter
+}
+
+// GraphQLOptions contains the request and response settings of the GraphQL adapter
+type GraphQLOptions struct {
+	// Query is the GraphQL document sent to the remote service. It is sent
+	// unchanged, since its selection sets use the same braces as the templates;
+	// the arguments reach the document through the variables.
+	Query string `json:"query"`
+
+	// OperationName selects the operation when the document has more than one
+	OperationName string `json:"operationName"`
+
+	// Variables is the template of the variables (e.g. {"codigo": "{codigoConvenio}"}).
+	// When empty, every argument is sent as a variable with the same name.
+	Variables map[string]interface{} `json:"variables"`
+
+	// ResponsePath selects the value returned from the "data" object of the
+	// response (e.g. "convenio" or "$.convenio.limites[0]")
+	ResponsePath string `json:"responsePath"`
+
+	// Errors indicates whether the remote errors fail the call (fail) or are
+	// ignored when the response still has data (partial)
+	Errors string `json:"errors"`
+
+	// StatusErrors maps HTTP status codes to the message of the GraphQL error
+	// reported to the client, as in the REST adapter
+	StatusErrors map[int]string `json:"statusErrors"`
+
+	// HTTP contains the HTTP client settings, as in the REST adapter
+	HTTP *HTTPClientOptions `json:"http"`
+
+	// SigV4 signs the requests with AWS Signature Version 4 (e.g. AppSync APIs
+	// with IAM authorization) instead of sending the bearer token
+	SigV4 *SigV4Options `json:"-"`
+
+	// AuthProfile authenticates the requests with a named auth profile instead
+	// of the bearer token
+	AuthProfile *types.AuthProfile `json:"-"`
+}
+
+// RemoteError is an error reported by a remote GraphQL service
+type RemoteError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLError is returned when the remote GraphQL service answers with errors.
+// Its message is sent to the clients, so the URL of the service is kept out of
+// it and is only meant to be logged.
+type GraphQLError struct {
+	URL    string
+	Errors []RemoteError
+}
+
+func (e *GraphQLError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, remote := range e.Errors {
+		messages = append(messages, remote.Message)
+	}
+	return fmt.Sprintf("remote GraphQL service returned errors: %s", strings.Join(messages, "; "))
+}
+
+type graphqlAdapter struct {
+	rest    *restAdapter
+	options GraphQLOptions
+	path    []string
+}
+
+// NewGraphQLAdapter creates an adapter that queries a remote GraphQL service.
+// The request is sent by a REST adapter, so the bearer token, the header
+// templates and the status code handling work the same way.
+func NewGraphQLAdapter(cfg *types.Config, baseUrl, endpoint string, auth bool, attributes, headers map[string]interface{}, options GraphQLOptions) (GraphQLAdapter, error) {
+	if strings.TrimSpace(options.Query) == "" {
+		return nil, fmt.Errorf("the GraphQL adapter requires a query")
+	}
+
+	options.Errors = strings.ToLower(options.Errors)
+	switch options.Errors {
+	case "":
+		options.Errors = GraphQLErrorsFail
+	case GraphQLErrorsFail, GraphQLErrorsPartial:
+	default:
+		return nil, fmt.Errorf("unsupported GraphQL errors policy: %s", options.Errors)
+	}
+
+	if err := validateTemplate(options.Variables); err != nil {
+		return nil, fmt.Errorf("invalid GraphQL variables template: %v", err)
+	}
+
+	adapter := &graphqlAdapter{options: options}
+	if options.ResponsePath != "" {
+		path, err := compilePath(options.ResponsePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GraphQL response path: %v", err)
+		}
+		adapter.path = path
+	}
+
+	if endpoint == "" {
+		endpoint = "graphql"
+	}
+
+	adapter.rest = NewRestAdapter(cfg, baseUrl, endpoint, auth, attributes, headers).(*restAdapter)
+	adapter.rest.method = http.MethodPost
+	adapter.rest.response.StatusErrors = options.StatusErrors
+
+	if options.HTTP != nil {
+		if _, err := adapter.rest.WithHTTPClient(*options.HTTP); err != nil {
+			return nil, err
+		}
+	}
+	if options.SigV4 != nil {
+		if _, err := adapter.rest.WithSigV4(*options.SigV4); err != nil {
+			return nil, err
+		}
+	}
+
+	// the auth profile is applied last, since the sts profiles start a token
+	// refresh loop that would leak if a later setting failed
+	if options.AuthProfile != nil {
+		if _, err := adapter.rest.WithAuthProfile(*options.AuthProfile); err != nil {
+			return nil, err
+		}
+	}
+	return adapter, nil
+}
+
+// newGraphQLFromSettings creates a GraphQL adapter from the connector settings
+func newGraphQLFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	headers, err := settings.StringMap("headers")
+	if err != nil {
+		return nil, err
+	}
+	auth, err := authSetting(settings)
+	if err != nil {
+		return nil, err
+	}
+	baseUrl, err := settings.RequiredString("baseUrl")
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := settings.String("endpoint")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("query"); err != nil {
+		return nil, err
+	}
+
+	var options GraphQLOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+	if auth == RestAuthSigV4 {
+		options.SigV4 = &SigV4Options{}
+		if err := settings.Decode(options.SigV4); err != nil {
+			return nil, err
+		}
+	}
+	if options.AuthProfile, err = authProfileSetting(settings, auth); err != nil {
+		return nil, err
+	}
+
+	cfg := settings.Config
+	if cfg == nil {
+		cfg = &types.Config{}
+	}
+
+	adapter, err := NewGraphQLAdapter(cfg, baseUrl, endpoint, auth == RestAuthBearer, attributes, headers, options)
+	return adapter, settings.wrap(err)
+}
+
+func (g *graphqlAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	variables, err := g.variables(args)
+	if err != nil {
+		return nil, err
+	}
+
+	request := map[string]interface{}{
+		"query":     g.options.Query,
+		"variables": variables,
+	}
+	if g.options.OperationName != "" {
+		request["operationName"] = g.options.OperationName
+	}
+
+	document, err := g.rest.do(ctx, args, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data   interface{}   `json:"data"`
+		Errors []RemoteError `json:"errors"`
+	}
+	if document != nil {
+		content, _ := json.Marshal(document)
+		if err := json.Unmarshal(content, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode GraphQL response: %v", err)
+		}
+	}
+
+	if len(response.Errors) > 0 && (g.options.Errors == GraphQLErrorsFail || response.Data == nil) {
+		return nil, &GraphQLError{URL: fmt.Sprintf("%s/%s", g.rest.baseUrl, g.rest.endpoint), Errors: response.Errors}
+	}
+
+	if g.path != nil {
+		return lookupPath(response.Data, g.path), nil
+	}
+	return response.Data, nil
+}
+
+// variables builds the variables of the request from the arguments
+func (g *graphqlAdapter) variables(args []AdapterAttribute) (interface{}, error) {
+	if len(g.options.Variables) == 0 {
+		variables := make(map[string]interface{}, len(args))
+		for _, arg := range args {
+			variables[arg.Name] = arg.Value
+		}
+		return variables, nil
+	}
+
+	variables, err := renderTemplate(g.options.Variables, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL variables: %v", err)
+	}
+	return variables, nil
+}
+
+// HealthCheck checks the GraphQL service the same way as a REST upstream
+func (g *graphqlAdapter) HealthCheck(ctx context.Context) error {
+	return g.rest.HealthCheck(ctx)
+}
+
+// Close stops the token refresh of the auth profile, like the REST adapter
+func (g *graphqlAdapter) Close() error {
+	return g.rest.Close()
+}
+
+func (g *graphqlAdapter) forwardsCallerToken() bool {
+	return g.rest.forwardsCallerToken()
+}
+
+func (g *graphqlAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return g.rest.GetParameters(args)
+}
@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+func TestGraphQLAdapter_Query(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			t.Errorf("requisição = %s %s, esperado POST /graphql", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Produto") != "consignado" {
+			t.Errorf("X-Produto = %q", r.Header.Get("X-Produto"))
+		}
+
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"data": {"convenio": {"codigo": 42, "nome": "INSS"}}}`))
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	adapter, err := NewGraphQLAdapter(cfg, server.URL, "", true,
+		map[string]interface{}{"codigoConvenio": "Int!", "produto": "String"},
+		map[string]interface{}{"X-Produto": "{produto}"},
+		GraphQLOptions{
+			Query:        "query Convenio($codigo: Int!) { convenio(codigo: $codigo) { codigo nome } }",
+			Variables:    map[string]interface{}{"codigo": "{codigoConvenio}"},
+			ResponsePath: "convenio",
+		})
+	if err != nil {
+		t.Fatalf("NewGraphQLAdapter() erro = %v", err)
+	}
+
+	params, err := adapter.GetParameters(map[string]interface{}{"codigoConvenio": 42, "produto": "consignado"})
+	if err != nil {
+		t.Fatalf("GetParameters() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	expected := map[string]interface{}{"codigo": 42.0, "nome": "INSS"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetData() = %v, esperado %v", result, expected)
+	}
+
+	if !reflect.DeepEqual(received["variables"], map[string]interface{}{"codigo": 42.0}) {
+		t.Errorf("variables = %v, esperado codigo 42", received["variables"])
+	}
+}
+
+func TestGraphQLAdapter_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"convenio": null, "taxa": 1.5}, "errors": [{"message": "convênio não encontrado", "path": ["convenio"]}]}`))
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{}
+	attributes := map[string]interface{}{"codigo": "Int"}
+	args := []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 1}}
+
+	failing, _ := NewGraphQLAdapter(cfg, server.URL, "", false, attributes, nil, GraphQLOptions{Query: "{ convenio { nome } taxa }"})
+	_, err := failing.GetData(context.Background(), args)
+
+	var graphqlErr *GraphQLError
+	if !errors.As(err, &graphqlErr) || len(graphqlErr.Errors) != 1 || graphqlErr.Errors[0].Message != "convênio não encontrado" {
+		t.Fatalf("GetData() erro = %v, esperado *GraphQLError", err)
+	}
+	if strings.Contains(err.Error(), server.URL) {
+		t.Errorf("GetData() erro = %v, a mensagem não deve expor o endereço do serviço", err)
+	}
+
+	partial, _ := NewGraphQLAdapter(cfg, server.URL, "", false, attributes, nil, GraphQLOptions{
+		Query:        "{ convenio { nome } taxa }",
+		ResponsePath: "taxa",
+		Errors:       GraphQLErrorsPartial,
+	})
+	result, err := partial.GetData(context.Background(), args)
+	if err != nil || result != 1.5 {
+		t.Errorf("GetData() = %v, erro = %v, esperado 1.5", result, err)
+	}
+}
+
+func TestGraphQLAdapter_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+	}{
+		{"sem query", map[string]interface{}{"baseUrl": "http://localhost"}},
+		{"sem baseUrl", map[string]interface{}{"query": "{ a }"}},
+		{"política de erros inválida", map[string]interface{}{"baseUrl": "http://localhost", "query": "{ a }", "errors": "retry"}},
+		{"variáveis inválidas", map[string]interface{}{"baseUrl": "http://localhost", "query": "{ a }", "variables": map[string]interface{}{"a": "{x|reverse}"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configErr *ConfigError
+			if _, err := New("graphql", Settings{Raw: tt.raw}); !errors.As(err, &configErr) {
+				t.Errorf("New() erro = %v, esperado *ConfigError", err)
+			}
+		})
+	}
+}
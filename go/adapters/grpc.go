@@ -0,0 +1,445 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func init() {
+	Register("grpc", newGRPCFromSettings)
+}
+
+type GRPCAdapter interface {
+	Adapter
+}
+
+// GRPCOptions contains the connection and invocation settings of the gRPC adapter
+type GRPCOptions struct {
+	// Target is the address of the server (e.g. "convenios.internal:443")
+	Target string `json:"target"`
+
+	// Method is the full name of the unary method (e.g. "convenio.v1.Convenios/Buscar")
+	Method string `json:"method"`
+
+	// DescriptorSet is the path of a binary FileDescriptorSet describing the
+	// method (protoc --descriptor_set_out --include_imports). When empty, the
+	// descriptors are loaded from the server reflection service (v1).
+	DescriptorSet string `json:"descriptorSet"`
+
+	// Request is the template of the request message, in its JSON form. When
+	// empty, every argument is sent as the field with the same name.
+	Request map[string]interface{} `json:"request"`
+
+	// Metadata contains the templates of the metadata headers sent with the call
+	Metadata map[string]string `json:"metadata"`
+
+	// Timeout is the deadline of each call (e.g. "2s")
+	Timeout string `json:"timeout"`
+
+	// ResponsePath selects the value returned from the response message
+	ResponsePath string `json:"responsePath"`
+
+	// NotFoundAsNull indicates that the NotFound code results in a null value
+	NotFoundAsNull bool `json:"notFoundAsNull"`
+
+	// UseProtoNames returns the fields with their proto names instead of lowerCamelCase
+	UseProtoNames bool `json:"useProtoNames"`
+
+	// TLS enables transport security. CAFile optionally replaces the system
+	// roots, and ServerName overrides the name verified in the certificate.
+	TLS                bool   `json:"tls"`
+	CAFile             string `json:"caFile"`
+	ServerName         string `json:"serverName"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+type grpcAdapter struct {
+	conn    *grpc.ClientConn
+	options GRPCOptions
+	method  string
+	timeout time.Duration
+	path    []string
+	attr    map[string]interface{}
+
+	mu         sync.Mutex
+	descriptor protoreflect.MethodDescriptor
+	resolving  chan struct{}
+}
+
+// NewGRPCAdapter creates an adapter that invokes an unary gRPC method, building
+// the request message from the arguments and converting the response with protojson
+func NewGRPCAdapter(options GRPCOptions, attributes map[string]interface{}) (GRPCAdapter, error) {
+	if options.Target == "" {
+		return nil, fmt.Errorf("the gRPC adapter requires the target")
+	}
+
+	method := strings.TrimPrefix(options.Method, "/")
+	if strings.Count(method, "/") != 1 || strings.HasPrefix(method, "/") || strings.HasSuffix(method, "/") {
+		return nil, fmt.Errorf("invalid gRPC method %q, expected package.Service/Method", options.Method)
+	}
+
+	if err := validateTemplate(options.Request); err != nil {
+		return nil, fmt.Errorf("invalid gRPC request template: %v", err)
+	}
+	for key, value := range options.Metadata {
+		if _, err := ParseTemplate(value); err != nil {
+			return nil, fmt.Errorf("invalid gRPC metadata %s: %v", key, err)
+		}
+	}
+
+	adapter := &grpcAdapter{
+		options: options,
+		method:  method,
+		attr:    attributes,
+	}
+
+	var err error
+	if options.Timeout != "" {
+		if adapter.timeout, err = time.ParseDuration(options.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid gRPC timeout: %v", err)
+		}
+	}
+
+	if options.ResponsePath != "" {
+		if adapter.path, err = compilePath(options.ResponsePath); err != nil {
+			return nil, fmt.Errorf("invalid gRPC response path: %v", err)
+		}
+	}
+
+	if options.DescriptorSet != "" {
+		if adapter.descriptor, err = loadDescriptorSet(options.DescriptorSet, method); err != nil {
+			return nil, err
+		}
+	}
+
+	transport := insecure.NewCredentials()
+	if options.TLS {
+		config := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         options.ServerName,
+			InsecureSkipVerify: options.InsecureSkipVerify,
+		}
+		if options.CAFile != "" {
+			content, err := os.ReadFile(options.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read gRPC CA file: %v", err)
+			}
+			config.RootCAs = x509.NewCertPool()
+			if !config.RootCAs.AppendCertsFromPEM(content) {
+				return nil, fmt.Errorf("no certificate found in gRPC CA file %s", options.CAFile)
+			}
+		}
+		transport = credentials.NewTLS(config)
+	}
+
+	// the connection is established on the first call
+	if adapter.conn, err = grpc.NewClient(options.Target, grpc.WithTransportCredentials(transport)); err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %v", options.Target, err)
+	}
+	return adapter, nil
+}
+
+// newGRPCFromSettings creates a gRPC adapter from the connector settings
+func newGRPCFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("target"); err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("method"); err != nil {
+		return nil, err
+	}
+
+	var options GRPCOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewGRPCAdapter(options, attributes)
+	return adapter, settings.wrap(err)
+}
+
+func (g *grpcAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	descriptor, err := g.methodDescriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := g.request(descriptor.Input(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range g.options.Metadata {
+		header, err := renderText(value, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build gRPC metadata %s: %v", key, err)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, key, header)
+	}
+
+	response := dynamicpb.NewMessage(descriptor.Output())
+	if err := g.conn.Invoke(ctx, "/"+g.method, request, response); err != nil {
+		if status.Code(err) == codes.NotFound && g.options.NotFoundAsNull {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to invoke gRPC method %s: %w", g.method, err)
+	}
+
+	content, err := protojson.MarshalOptions{UseProtoNames: g.options.UseProtoNames, EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gRPC response: %v", err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode gRPC response: %v", err)
+	}
+
+	if g.path != nil {
+		return lookupPath(data, g.path), nil
+	}
+	return data, nil
+}
+
+// request builds the request message from the arguments
+func (g *grpcAdapter) request(input protoreflect.MessageDescriptor, args []AdapterAttribute) (proto.Message, error) {
+	var body interface{}
+	if len(g.options.Request) == 0 {
+		fields := make(map[string]interface{}, len(args))
+		for _, arg := range args {
+			if arg.Value != nil {
+				fields[arg.Name] = arg.Value
+			}
+		}
+		body = fields
+	} else {
+		var err error
+		if body, err = renderTemplate(g.options.Request, args); err != nil {
+			return nil, fmt.Errorf("failed to build gRPC request: %v", err)
+		}
+	}
+
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gRPC request: %v", err)
+	}
+
+	message := dynamicpb.NewMessage(input)
+	if err := protojson.Unmarshal(content, message); err != nil {
+		return nil, fmt.Errorf("invalid gRPC request for %s: %v", input.FullName(), err)
+	}
+	return message, nil
+}
+
+// methodDescriptor returns the descriptor of the method, loading it from the
+// server reflection service on the first call. A single call resolves it at a
+// time, without holding the lock, while the concurrent calls wait for its result
+// or their own context; when the resolution fails, the next call tries again.
+func (g *grpcAdapter) methodDescriptor(ctx context.Context) (protoreflect.MethodDescriptor, error) {
+	for {
+		g.mu.Lock()
+		if g.descriptor != nil {
+			descriptor := g.descriptor
+			g.mu.Unlock()
+			return descriptor, nil
+		}
+
+		if g.resolving == nil {
+			done := make(chan struct{})
+			g.resolving = done
+			g.mu.Unlock()
+
+			descriptor, err := g.resolve(ctx)
+
+			g.mu.Lock()
+			if err == nil {
+				g.descriptor = descriptor
+			}
+			g.resolving = nil
+			g.mu.Unlock()
+			close(done)
+			return descriptor, err
+		}
+
+		resolving := g.resolving
+		g.mu.Unlock()
+
+		select {
+		case <-resolving:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// resolve loads the descriptor of the method from the server reflection service
+func (g *grpcAdapter) resolve(ctx context.Context) (protoreflect.MethodDescriptor, error) {
+	files, err := reflectFiles(ctx, g.conn, serviceName(g.method))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC descriptors of %s by reflection: %v", g.method, err)
+	}
+	return findMethod(files, g.method)
+}
+
+// Close closes the connection to the gRPC server
+func (g *grpcAdapter) Close() error {
+	return g.conn.Close()
+}
+
+func (g *grpcAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return getParameters(g.attr, args)
+}
+
+// loadDescriptorSet reads a FileDescriptorSet and finds the method on it
+func loadDescriptorSet(path, method string) (protoreflect.MethodDescriptor, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gRPC descriptor set: %v", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid gRPC descriptor set %s: %v", path, err)
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC descriptor set %s: %v", path, err)
+	}
+	return findMethod(files, method)
+}
+
+// findMethod finds the descriptor of a method named as package.Service/Method
+func findMethod(files *protoregistry.Files, method string) (protoreflect.MethodDescriptor, error) {
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName(method)))
+	if err != nil {
+		return nil, fmt.Errorf("gRPC service %s not found: %v", serviceName(method), err)
+	}
+
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gRPC service", serviceName(method))
+	}
+
+	name := method[strings.Index(method, "/")+1:]
+	found := service.Methods().ByName(protoreflect.Name(name))
+	if found == nil {
+		return nil, fmt.Errorf("gRPC method %s not found in %s", name, service.FullName())
+	}
+	if found.IsStreamingClient() || found.IsStreamingServer() {
+		return nil, fmt.Errorf("gRPC method %s is not unary", method)
+	}
+	return found, nil
+}
+
+// reflectFiles loads the file that defines the service, and its dependencies,
+// from the server reflection service
+func reflectFiles(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	received := make(map[string]*descriptorpb.FileDescriptorProto)
+	ask := func(request *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(request); err != nil {
+			return err
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if failure := response.GetErrorResponse(); failure != nil {
+			return fmt.Errorf("%s", failure.GetErrorMessage())
+		}
+		for _, content := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(content, file); err != nil {
+				return err
+			}
+			received[file.GetName()] = file
+		}
+		return nil
+	}
+
+	err = ask(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the dependencies not sent by the server are requested one by one, or
+	// taken from the types linked to the binary (e.g. google/protobuf/*.proto)
+	set := &descriptorpb.FileDescriptorSet{}
+	pending := make([]string, 0, len(received))
+	for name := range received {
+		pending = append(pending, name)
+	}
+	added := make(map[string]bool)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if added[name] {
+			continue
+		}
+
+		file, exists := received[name]
+		if !exists {
+			if linked, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+				file = protodesc.ToFileDescriptorProto(linked)
+			} else {
+				err := ask(&reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+				})
+				if err != nil || received[name] == nil {
+					return nil, fmt.Errorf("dependency %s not found: %v", name, err)
+				}
+				file = received[name]
+			}
+		}
+
+		added[name] = true
+		set.File = append(set.File, file)
+		pending = append(pending, file.GetDependency()...)
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+// serviceName returns the service part of a package.Service/Method name
+func serviceName(method string) string {
+	return method[:strings.Index(method, "/")]
+}
@@ -0,0 +1,204 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// convenioProto descreve o serviço convenio.v1.Convenios usado nos testes
+func convenioProto() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     kind.Enum(),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("convenio.proto"),
+		Package: proto.String("convenio.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("BuscarRequest"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("codigo", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+			}},
+			{Name: proto.String("Convenio"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("codigo", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				field("nome", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("canal", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Convenios"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Buscar"),
+				InputType:  proto.String(".convenio.v1.BuscarRequest"),
+				OutputType: proto.String(".convenio.v1.Convenio"),
+			}},
+		}},
+	}
+}
+
+// startConvenioServer inicia um servidor gRPC com reflexão que responde ao método Buscar
+func startConvenioServer(t *testing.T) string {
+	file, err := protodesc.NewFile(convenioProto(), nil)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() erro = %v", err)
+	}
+	files := &protoregistry.Files{}
+	files.RegisterFile(file)
+
+	input := file.Messages().ByName("BuscarRequest")
+	output := file.Messages().ByName("Convenio")
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "convenio.v1.Convenios",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Buscar",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := dynamicpb.NewMessage(input)
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+
+				codigo := request.Get(input.Fields().ByName("codigo")).Int()
+				if codigo == 404 {
+					return nil, status.Error(codes.NotFound, "convênio não encontrado")
+				}
+
+				md, _ := metadata.FromIncomingContext(ctx)
+				response := dynamicpb.NewMessage(output)
+				response.Set(output.Fields().ByName("codigo"), protoreflect.ValueOfInt32(int32(codigo)))
+				response.Set(output.Fields().ByName("nome"), protoreflect.ValueOfString("INSS"))
+				if values := md.Get("x-canal"); len(values) > 0 {
+					response.Set(output.Fields().ByName("canal"), protoreflect.ValueOfString(values[0]))
+				}
+				return response, nil
+			},
+		}},
+	}, struct{}{})
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServerV1(reflection.ServerOptions{
+		Services:           server,
+		DescriptorResolver: files,
+	}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() erro = %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGRPCAdapter_Reflection(t *testing.T) {
+	target := startConvenioServer(t)
+
+	adapter, err := NewGRPCAdapter(GRPCOptions{
+		Target:         target,
+		Method:         "convenio.v1.Convenios/Buscar",
+		Request:        map[string]interface{}{"codigo": "{codigoConvenio}"},
+		Metadata:       map[string]string{"x-canal": "{canal|upper}"},
+		Timeout:        "2s",
+		NotFoundAsNull: true,
+	}, map[string]interface{}{"codigoConvenio": "Int!", "canal": "String"})
+	if err != nil {
+		t.Fatalf("NewGRPCAdapter() erro = %v", err)
+	}
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"codigoConvenio": 42, "canal": "app"})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	expected := map[string]interface{}{"codigo": 42.0, "nome": "INSS", "canal": "APP"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetData() = %v, esperado %v", result, expected)
+	}
+
+	params, _ = adapter.GetParameters(map[string]interface{}{"codigoConvenio": 404, "canal": "app"})
+	if result, err := adapter.GetData(context.Background(), params); err != nil || result != nil {
+		t.Errorf("GetData() = %v, erro = %v, esperado nil para NotFound", result, err)
+	}
+}
+
+func TestGRPCAdapter_DescriptorSet(t *testing.T) {
+	target := startConvenioServer(t)
+
+	content, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{convenioProto()}})
+	if err != nil {
+		t.Fatalf("proto.Marshal() erro = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "convenio.pb")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() erro = %v", err)
+	}
+
+	adapter, err := New("grpc", Settings{Raw: map[string]interface{}{
+		"target":        target,
+		"method":        "/convenio.v1.Convenios/Buscar",
+		"descriptorSet": path,
+		"responsePath":  "nome",
+		"attr":          map[string]interface{}{"codigo": "Int"},
+	}})
+	if err != nil {
+		t.Fatalf("New() erro = %v", err)
+	}
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"codigo": 7})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil || result != "INSS" {
+		t.Errorf("GetData() = %v, erro = %v, esperado INSS", result, err)
+	}
+
+	params, _ = adapter.GetParameters(map[string]interface{}{"codigo": 404})
+	if _, err := adapter.GetData(context.Background(), params); status.Code(errors.Unwrap(err)) != codes.NotFound {
+		t.Errorf("GetData() erro = %v, esperado NotFound", err)
+	}
+}
+
+func TestGRPCAdapter_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+	}{
+		{"sem target", map[string]interface{}{"method": "a.B/C"}},
+		{"método inválido", map[string]interface{}{"target": "localhost:1", "method": "Buscar"}},
+		{"descriptor set inexistente", map[string]interface{}{"target": "localhost:1", "method": "a.B/C", "descriptorSet": "/nao/existe.pb"}},
+		{"timeout inválido", map[string]interface{}{"target": "localhost:1", "method": "a.B/C", "timeout": "dois"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configErr *ConfigError
+			if _, err := New("grpc", Settings{Raw: tt.raw}); !errors.As(err, &configErr) {
+				t.Errorf("New() erro = %v, esperado *ConfigError", err)
+			}
+		})
+	}
+}
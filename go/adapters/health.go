@@ -0,0 +1,25 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrHealthCheckUnsupported is returned when the adapter cannot check its dependency
+var ErrHealthCheckUnsupported = errors.New("the adapter does not support health checks")
+
+// HealthChecker is implemented by the adapters able to verify whether their
+// dependency is reachable without resolving a field (e.g. a Redis PING)
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// CheckHealth checks the dependency of the adapter. It returns
+// ErrHealthCheckUnsupported when the adapter does not implement HealthChecker.
+func CheckHealth(ctx context.Context, adapter Adapter) error {
+	checker, ok := adapter.(HealthChecker)
+	if !ok {
+		return ErrHealthCheckUnsupported
+	}
+	return checker.HealthCheck(ctx)
+}
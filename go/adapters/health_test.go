@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+func TestHealthCheck_Redis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run() erro = %v", err)
+	}
+
+	adapter, err := NewRedisAdapter(mr.Addr(), "", "convenio:{codigo}", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+	if err := CheckHealth(context.Background(), adapter); err != nil {
+		t.Errorf("CheckHealth() erro = %v", err)
+	}
+
+	mr.Close()
+	if err := CheckHealth(context.Background(), adapter); err == nil {
+		t.Errorf("CheckHealth() esperado erro com o Redis fora do ar")
+	}
+}
+
+func TestHealthCheck_Rest(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("método = %s, esperado HEAD", r.Method)
+		}
+		w.WriteHeader(status)
+	}))
+
+	adapter := NewRestAdapter(&types.Config{}, server.URL, "convenios/{codigo}", false, nil, nil)
+	if err := CheckHealth(context.Background(), adapter); err != nil {
+		t.Errorf("CheckHealth() erro = %v, esperado sucesso para 404", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	var coded interface{ Status() int }
+	if err := CheckHealth(context.Background(), adapter); !errors.As(err, &coded) || coded.Status() != status {
+		t.Errorf("CheckHealth() erro = %v, esperado status 503", err)
+	}
+
+	server.Close()
+	if err := CheckHealth(context.Background(), adapter); err == nil {
+		t.Errorf("CheckHealth() esperado erro com o servidor fora do ar")
+	}
+}
+
+func TestHealthCheck_AWS(t *testing.T) {
+	dynamo := &mockDynamoDB{}
+	if err := CheckHealth(context.Background(), &dynamoDBAdapter{client: dynamo, table: "convenios"}); err != nil {
+		t.Errorf("CheckHealth() erro = %v", err)
+	}
+	if !reflect.DeepEqual(dynamo.tables, []string{"convenios"}) {
+		t.Errorf("DescribeTable = %v, esperado [convenios]", dynamo.tables)
+	}
+
+	bucket := &mockS3{}
+	if err := CheckHealth(context.Background(), &s3Adapter{client: bucket, bucket: "convenios"}); err != nil {
+		t.Errorf("CheckHealth() erro = %v", err)
+	}
+	if !reflect.DeepEqual(bucket.keys, []string{"convenios"}) {
+		t.Errorf("HeadBucket = %v, esperado [convenios]", bucket.keys)
+	}
+}
+
+// Adapter com verificação de saúde programável
+type healthAdapter struct {
+	mockAdapter
+	checks int
+	health error
+}
+
+func (h *healthAdapter) HealthCheck(ctx context.Context) error {
+	h.checks++
+	return h.health
+}
+
+func TestHealthCheck_Wrappers(t *testing.T) {
+	failure := errors.New("dependência indisponível")
+	inner := &healthAdapter{
+		mockAdapter: mockAdapter{err: func(call int32) error { return &statusError{code: http.StatusServiceUnavailable} }},
+		health:      failure,
+	}
+
+	resilient := NewResilientAdapter(inner, ResiliencePolicy{
+		MaxAttempts: 1,
+		Breaker:     &BreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Hour},
+	})
+	adapter := NewCachedAdapter(NewCoalescingAdapter(resilient), CachePolicy{TTL: time.Minute})
+
+	// o circuito aberto não impede a verificação da dependência
+	adapter.GetData(context.Background(), nil)
+	if _, err := resilient.GetData(context.Background(), nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetData() erro = %v, esperado circuito aberto", err)
+	}
+
+	if err := CheckHealth(context.Background(), adapter); !errors.Is(err, failure) {
+		t.Errorf("CheckHealth() erro = %v, esperado %v", err, failure)
+	}
+	if inner.checks != 1 {
+		t.Errorf("verificações = %d, esperado 1", inner.checks)
+	}
+
+	unsupported := NewCachedAdapter(&mockAdapter{err: func(call int32) error { return nil }}, CachePolicy{TTL: time.Minute})
+	if err := CheckHealth(context.Background(), unsupported); !errors.Is(err, ErrHealthCheckUnsupported) {
+		t.Errorf("CheckHealth() erro = %v, esperado ErrHealthCheckUnsupported", err)
+	}
+}
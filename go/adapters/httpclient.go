@@ -0,0 +1,166 @@
+package adapters
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHTTPTimeout is the timeout of the HTTP clients without their own settings
+const defaultHTTPTimeout = 10 * time.Second
+
+// HTTPClientOptions contains the HTTP client settings of the adapters that call
+// HTTP upstreams. Adapters pointing to the same host with the same transport
+// settings share their connections.
+type HTTPClientOptions struct {
+	// Timeout limits the whole request, including the response body (e.g.
+	// "30s"). It is 10 seconds when empty.
+	Timeout string `json:"timeout"`
+
+	// MaxIdleConns and MaxIdleConnsPerHost limit the idle connections kept
+	// open, and IdleConnTimeout closes them after a while (e.g. "90s")
+	MaxIdleConns        int    `json:"maxIdleConns"`
+	MaxIdleConnsPerHost int    `json:"maxIdleConnsPerHost"`
+	IdleConnTimeout     string `json:"idleConnTimeout"`
+
+	// CertFile and KeyFile are the PEM encoded client certificate and key
+	// presented to upstreams that require mutual TLS
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+
+	// CAFile is a PEM bundle of certificate authorities trusted in addition
+	// to the ones of the system
+	CAFile string `json:"caFile"`
+
+	// ServerName overrides the name verified in the certificate of the upstream
+	ServerName string `json:"serverName"`
+
+	// InsecureSkipVerify disables the verification of the upstream certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+
+	// Proxy is the address of the proxy used by the requests. When empty, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables are used.
+	Proxy string `json:"proxy"`
+
+	// HTTP2 enables HTTP/2 with upstreams that support it (enabled unless false)
+	HTTP2 *bool `json:"http2"`
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = make(map[string]*http.Transport)
+)
+
+// newHTTPClient creates a client for the upstream of the base URL, reusing
+// the transport already created for the same host and transport settings
+func newHTTPClient(baseUrl string, options HTTPClientOptions) (*http.Client, error) {
+	timeout := defaultHTTPTimeout
+	if options.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(options.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid HTTP timeout %q: %v", options.Timeout, err)
+		}
+	}
+
+	upstream, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream URL %s: %v", baseUrl, err)
+	}
+
+	// the timeout belongs to the client, every other setting to the transport
+	options.Timeout = ""
+	settings, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.ToLower(upstream.Host) + " " + string(settings)
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	transport, exists := transports[key]
+	if !exists {
+		if transport, err = newTransport(options); err != nil {
+			return nil, err
+		}
+		transports[key] = transport
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+// newTransport creates a transport with the connection pool, TLS and proxy settings
+func newTransport(options HTTPClientOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+	}
+	if options.IdleConnTimeout != "" {
+		timeout, err := time.ParseDuration(options.IdleConnTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP idle connection timeout %q: %v", options.IdleConnTimeout, err)
+		}
+		transport.IdleConnTimeout = timeout
+	}
+
+	if options.Proxy != "" {
+		proxy, err := url.Parse(options.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid HTTP proxy %q", options.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         options.ServerName,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	if options.CertFile != "" || options.KeyFile != "" {
+		if options.CertFile == "" || options.KeyFile == "" {
+			return nil, fmt.Errorf("the client certificate requires both the certFile and the keyFile")
+		}
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if options.CAFile != "" {
+		bundle, err := os.ReadFile(options.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle: %v", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("the CA bundle %s has no valid certificate", options.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if options.HTTP2 != nil && !*options.HTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return transport, nil
+}
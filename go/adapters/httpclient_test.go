@@ -0,0 +1,177 @@
+package adapters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+// writeClientCertificate gera um certificado de cliente autoassinado e grava o
+// certificado e a chave em arquivos PEM
+func writeClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() erro = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "graphql-consignado"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() erro = %v", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certificate, certFile, keyFile
+}
+
+// writeServerCA grava o certificado do servidor de teste como bundle de CAs
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)
+	return caFile
+}
+
+func TestRestAdapter_WithHTTPClient_MutualTLS(t *testing.T) {
+	certificate, certFile, keyFile := writeClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"parceiro": "ok"}}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writeServerCA(t, server)
+
+	// sem o certificado de cliente o parceiro recusa a conexão
+	adapter, err := NewRestAdapter(&types.Config{}, server.URL, "parceiros", false, nil, nil).
+		WithHTTPClient(HTTPClientOptions{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("WithHTTPClient() erro = %v", err)
+	}
+	if _, err := adapter.GetData(context.Background(), nil); err == nil {
+		t.Errorf("GetData() esperado erro sem o certificado de cliente")
+	}
+
+	adapter, err = NewRestAdapter(&types.Config{}, server.URL, "parceiros", false, nil, nil).
+		WithHTTPClient(HTTPClientOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("WithHTTPClient() erro = %v", err)
+	}
+	result, err := adapter.GetData(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+	if result.(map[string]interface{})["parceiro"] != "ok" {
+		t.Errorf("GetData() = %v", result)
+	}
+}
+
+func TestRestAdapter_WithHTTPClient_Settings(t *testing.T) {
+	var protocols []int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols = append(protocols, r.ProtoMajor)
+		if r.URL.Path == "/lento" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"data": 1}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writeServerCA(t, server)
+	disabled := false
+
+	for _, options := range []HTTPClientOptions{
+		{CAFile: caFile},
+		{CAFile: caFile, HTTP2: &disabled},
+	} {
+		adapter, err := NewRestAdapter(&types.Config{}, server.URL, "rapido", false, nil, nil).WithHTTPClient(options)
+		if err != nil {
+			t.Fatalf("WithHTTPClient() erro = %v", err)
+		}
+		if _, err := adapter.GetData(context.Background(), nil); err != nil {
+			t.Fatalf("GetData() erro = %v", err)
+		}
+	}
+	if len(protocols) != 2 || protocols[0] != 2 || protocols[1] != 1 {
+		t.Errorf("protocolos = %v, esperado [2 1]", protocols)
+	}
+
+	adapter, _ := NewRestAdapter(&types.Config{}, server.URL, "lento", false, nil, nil).
+		WithHTTPClient(HTTPClientOptions{CAFile: caFile, Timeout: "50ms"})
+	if _, err := adapter.GetData(context.Background(), nil); err == nil {
+		t.Errorf("GetData() esperado erro de timeout")
+	}
+}
+
+func TestNewHTTPClient_SharedTransports(t *testing.T) {
+	first, _ := newHTTPClient("https://parceiro.example.com/v1", HTTPClientOptions{MaxIdleConnsPerHost: 20})
+	second, _ := newHTTPClient("https://PARCEIRO.example.com/v2", HTTPClientOptions{MaxIdleConnsPerHost: 20, Timeout: "30s"})
+	other, _ := newHTTPClient("https://outro.example.com", HTTPClientOptions{MaxIdleConnsPerHost: 20})
+	tuned, _ := newHTTPClient("https://parceiro.example.com", HTTPClientOptions{MaxIdleConnsPerHost: 50})
+
+	if first.Transport != second.Transport {
+		t.Errorf("esperado o mesmo transporte para o mesmo host e configuração")
+	}
+	if first.Transport == other.Transport || first.Transport == tuned.Transport {
+		t.Errorf("esperado transportes distintos para outro host ou outra configuração")
+	}
+	if first.Timeout != defaultHTTPTimeout || second.Timeout != 30*time.Second {
+		t.Errorf("timeouts = %v e %v", first.Timeout, second.Timeout)
+	}
+	if transport := first.Transport.(*http.Transport); transport.MaxIdleConnsPerHost != 20 {
+		t.Errorf("MaxIdleConnsPerHost = %d, esperado 20", transport.MaxIdleConnsPerHost)
+	}
+}
+
+func TestRestAdapter_WithHTTPClient_InvalidConfig(t *testing.T) {
+	for _, options := range []map[string]interface{}{
+		{"timeout": "dez segundos"},
+		{"idleConnTimeout": "1x"},
+		{"proxy": "proxy-sem-esquema"},
+		{"certFile": "client.pem"},
+		{"certFile": "inexistente.pem", "keyFile": "inexistente-key.pem"},
+		{"caFile": "inexistente.pem"},
+	} {
+		var configErr *ConfigError
+		_, err := New("rest", Settings{Raw: map[string]interface{}{"baseUrl": "https://parceiro.example.com", "http": options}})
+		if !errors.As(err, &configErr) {
+			t.Errorf("New(%v) erro = %v, esperado *ConfigError", options, err)
+		}
+	}
+}
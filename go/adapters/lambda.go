@@ -0,0 +1,186 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+func init() {
+	Register("lambda", newLambdaFromSettings)
+}
+
+type LambdaAdapter interface {
+	Adapter
+}
+
+// LambdaOptions contains the invocation settings of the Lambda adapter
+type LambdaOptions struct {
+	// Region is the AWS region of the function
+	Region string `json:"region"`
+
+	// Function is the name or ARN of the function
+	Function string `json:"function"`
+
+	// Qualifier is the optional version or alias invoked
+	Qualifier string `json:"qualifier"`
+
+	// Payload is the template of the JSON event sent to the function. When
+	// empty, every argument is sent as the attribute with the same name.
+	Payload interface{} `json:"payload"`
+
+	// ResponsePath selects the value returned from the function response
+	ResponsePath string `json:"responsePath"`
+
+	// Endpoint overrides the Lambda endpoint (e.g. a local runtime emulator)
+	Endpoint string `json:"endpoint"`
+
+	// AccessKeyId and SecretAccessKey are optional static credentials. When
+	// empty, the default credential chain is used.
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
+// FunctionError is returned when the function invoked fails while handling the event
+type FunctionError struct {
+	Function string
+	Type     string `json:"errorType"`
+	Message  string `json:"errorMessage"`
+}
+
+func (e *FunctionError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("lambda function %s failed: %s", e.Function, e.Message)
+	}
+	return fmt.Sprintf("lambda function %s failed with %s: %s", e.Function, e.Type, e.Message)
+}
+
+// lambdaAPI contains the operations of the Lambda client used by the adapter
+type lambdaAPI interface {
+	Invoke(ctx context.Context, params *lambda.InvokeInput, optFns ...func(*lambda.Options)) (*lambda.InvokeOutput, error)
+}
+
+type lambdaAdapter struct {
+	client  lambdaAPI
+	options LambdaOptions
+	path    []string
+	attr    map[string]interface{}
+}
+
+// NewLambdaAdapter creates an adapter that invokes a function synchronously
+// and returns its JSON response
+func NewLambdaAdapter(options LambdaOptions, attributes map[string]interface{}) (LambdaAdapter, error) {
+	if options.Function == "" {
+		return nil, fmt.Errorf("the lambda adapter requires the function name")
+	}
+
+	if err := validateTemplate(options.Payload); err != nil {
+		return nil, fmt.Errorf("invalid lambda payload template: %v", err)
+	}
+
+	adapter := &lambdaAdapter{options: options, attr: attributes}
+	if options.ResponsePath != "" {
+		path, err := compilePath(options.ResponsePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lambda response path: %v", err)
+		}
+		adapter.path = path
+	}
+
+	cfg, err := loadAWSConfig(context.Background(), AWSOptions{
+		Region:          options.Region,
+		Endpoint:        options.Endpoint,
+		AccessKeyId:     options.AccessKeyId,
+		SecretAccessKey: options.SecretAccessKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	adapter.client = lambda.NewFromConfig(cfg)
+	return adapter, nil
+}
+
+// newLambdaFromSettings creates a Lambda adapter from the connector settings
+func newLambdaFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("function"); err != nil {
+		return nil, err
+	}
+
+	var options LambdaOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewLambdaAdapter(options, attributes)
+	return adapter, settings.wrap(err)
+}
+
+func (l *lambdaAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	var (
+		payload interface{}
+		err     error
+	)
+
+	if l.options.Payload == nil {
+		event := make(map[string]interface{}, len(args))
+		for _, arg := range args {
+			event[arg.Name] = arg.Value
+		}
+		payload = event
+	} else if payload, err = renderTemplate(l.options.Payload, args); err != nil {
+		return nil, fmt.Errorf("failed to build lambda payload: %v", err)
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lambda payload: %v", err)
+	}
+
+	input := &lambda.InvokeInput{
+		FunctionName: aws.String(l.options.Function),
+		Payload:      content,
+	}
+	if l.options.Qualifier != "" {
+		input.Qualifier = aws.String(l.options.Qualifier)
+	}
+
+	output, err := l.client.Invoke(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke lambda function %s: %w", l.options.Function, err)
+	}
+
+	if output.FunctionError != nil {
+		failure := &FunctionError{Function: l.options.Function, Type: aws.ToString(output.FunctionError)}
+		if err := json.Unmarshal(output.Payload, failure); err != nil || failure.Message == "" {
+			failure.Message = string(output.Payload)
+		}
+		return nil, failure
+	}
+
+	if len(bytes.TrimSpace(output.Payload)) == 0 {
+		return nil, nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(output.Payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode lambda response: %v", err)
+	}
+
+	if l.path != nil {
+		return lookupPath(data, l.path), nil
+	}
+	return data, nil
+}
+
+func (l *lambdaAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return getParameters(l.attr, args)
+}
@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newLambdaEmulator simula a API de invocação do Lambda
+func newLambdaEmulator(t *testing.T, handler func(event map[string]interface{}) (int, string, string)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2015-03-31/functions/consulta-convenio/invocations" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+
+		var event map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&event)
+
+		status, functionError, body := handler(event)
+		if functionError != "" {
+			w.Header().Set("X-Amz-Function-Error", functionError)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestLambdaAdapter(t *testing.T, endpoint string, payload interface{}) Adapter {
+	adapter, err := NewLambdaAdapter(LambdaOptions{
+		Region:          "us-east-1",
+		Function:        "consulta-convenio",
+		Payload:         payload,
+		Endpoint:        endpoint,
+		AccessKeyId:     "test",
+		SecretAccessKey: "test",
+	}, map[string]interface{}{"codigoConvenio": "Int!"})
+	if err != nil {
+		t.Fatalf("NewLambdaAdapter() erro = %v", err)
+	}
+	return adapter
+}
+
+func TestLambdaAdapter_Invoke(t *testing.T) {
+	var received map[string]interface{}
+	server := newLambdaEmulator(t, func(event map[string]interface{}) (int, string, string) {
+		received = event
+		return http.StatusOK, "", `{"codigo": 42, "nome": "INSS"}`
+	})
+
+	adapter := newTestLambdaAdapter(t, server.URL, map[string]interface{}{
+		"pathParameters": map[string]interface{}{"codigo": "{codigoConvenio}"},
+	})
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"codigoConvenio": 42})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if !reflect.DeepEqual(result, map[string]interface{}{"codigo": 42.0, "nome": "INSS"}) {
+		t.Errorf("GetData() = %v", result)
+	}
+	if !reflect.DeepEqual(received, map[string]interface{}{"pathParameters": map[string]interface{}{"codigo": 42.0}}) {
+		t.Errorf("evento recebido = %v", received)
+	}
+}
+
+func TestLambdaAdapter_DefaultPayload(t *testing.T) {
+	var received map[string]interface{}
+	server := newLambdaEmulator(t, func(event map[string]interface{}) (int, string, string) {
+		received = event
+		return http.StatusOK, "", ""
+	})
+
+	adapter := newTestLambdaAdapter(t, server.URL, nil)
+	params, _ := adapter.GetParameters(map[string]interface{}{"codigoConvenio": 7})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil || result != nil {
+		t.Errorf("GetData() = %v, erro = %v, esperado nil", result, err)
+	}
+	if received["codigoConvenio"] != 7.0 {
+		t.Errorf("evento recebido = %v, esperado codigoConvenio 7", received)
+	}
+}
+
+func TestLambdaAdapter_FunctionError(t *testing.T) {
+	server := newLambdaEmulator(t, func(event map[string]interface{}) (int, string, string) {
+		return http.StatusOK, "Unhandled", `{"errorType": "ConvenioNaoEncontrado", "errorMessage": "convênio 42 não encontrado"}`
+	})
+
+	adapter := newTestLambdaAdapter(t, server.URL, nil)
+	params, _ := adapter.GetParameters(map[string]interface{}{"codigoConvenio": 42})
+	_, err := adapter.GetData(context.Background(), params)
+
+	var functionErr *FunctionError
+	if !errors.As(err, &functionErr) {
+		t.Fatalf("GetData() erro = %v, esperado *FunctionError", err)
+	}
+	if functionErr.Type != "ConvenioNaoEncontrado" || functionErr.Message != "convênio 42 não encontrado" {
+		t.Errorf("FunctionError = %+v", functionErr)
+	}
+}
+
+func TestLambdaAdapter_InvalidConfig(t *testing.T) {
+	for _, raw := range []map[string]interface{}{
+		{},
+		{"function": "f", "payload": map[string]interface{}{"a": "{x|reverse}"}},
+		{"function": "f", "responsePath": "$["},
+	} {
+		var configErr *ConfigError
+		if _, err := New("lambda", Settings{Raw: raw}); !errors.As(err, &configErr) {
+			t.Errorf("New(%v) erro = %v, esperado *ConfigError", raw, err)
+		}
+	}
+}
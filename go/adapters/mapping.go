@@ -0,0 +1,203 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// MappingField describes how a field of the result is read from the upstream item
+type MappingField struct {
+	// Path is a JSON pointer or JSONPath selecting the value in the upstream
+	// item (e.g. "nm_convenio" or "$.limites.disponivel"). When empty, the
+	// field with the same name is read.
+	Path string
+
+	// Default is the value used when the path is missing or null
+	Default interface{}
+
+	// Type casts the value, using the GraphQL notation of the "attr" settings
+	// (e.g. "Int", "Float!" or "[String]"). When empty, the value is kept.
+	Type string
+}
+
+// MappingPolicy contains the fields of the result, by name. Lists are mapped
+// item by item and any other value is returned unchanged.
+type MappingPolicy struct {
+	Fields map[string]MappingField
+
+	// ItemsPath selects the items inside an envelope (e.g. "$.hits" for the
+	// OpenSearch results or "$.items" for the REST passthrough pages). Only the
+	// items are mapped and the rest of the envelope is kept.
+	ItemsPath string
+
+	// KeepUnmapped copies every field of the upstream item to the result
+	// before the mapped fields are applied
+	KeepUnmapped bool
+}
+
+// mappingField is a MappingField with its path and type parsed
+type mappingField struct {
+	name         string
+	path         []string
+	defaultValue interface{}
+	kind         *argumentType
+}
+
+type mappingAdapter struct {
+	Adapter
+	fields       []mappingField
+	keepUnmapped bool
+	itemsPath    []string
+}
+
+// NewMappingAdapter wraps the adapter, reshaping its results into the fields of
+// the GraphQL type: fields are renamed, read from nested paths, filled with
+// defaults and cast to the declared types.
+func NewMappingAdapter(adapter Adapter, policy MappingPolicy) (Adapter, error) {
+	if len(policy.Fields) == 0 {
+		return nil, fmt.Errorf("the mapping requires at least one field")
+	}
+
+	mapping := &mappingAdapter{
+		Adapter:      adapter,
+		fields:       make([]mappingField, 0, len(policy.Fields)),
+		keepUnmapped: policy.KeepUnmapped,
+	}
+
+	if policy.ItemsPath != "" {
+		var err error
+		if mapping.itemsPath, err = compilePath(policy.ItemsPath); err != nil {
+			return nil, fmt.Errorf("invalid mapping items path: %v", err)
+		}
+	}
+
+	for name, field := range policy.Fields {
+		compiled := mappingField{name: name, path: []string{name}, defaultValue: field.Default}
+
+		var err error
+		if field.Path != "" {
+			if compiled.path, err = compilePath(field.Path); err != nil {
+				return nil, fmt.Errorf("invalid mapping path of %s: %v", name, err)
+			}
+		}
+		if field.Type != "" {
+			if compiled.kind, err = parseArgumentType(field.Type); err != nil {
+				return nil, fmt.Errorf("invalid mapping type of %s: %v", name, err)
+			}
+		}
+		mapping.fields = append(mapping.fields, compiled)
+	}
+
+	// the fields are applied in a stable order, so that errors are reproducible
+	sort.Slice(mapping.fields, func(i, j int) bool {
+		return mapping.fields[i].name < mapping.fields[j].name
+	})
+	return mapping, nil
+}
+
+func (m *mappingAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	data, err := m.Adapter.GetData(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return m.envelope(data, m.itemsPath)
+}
+
+// HealthCheck checks the wrapped adapter, since the mapping has no dependency
+func (m *mappingAdapter) HealthCheck(ctx context.Context) error {
+	return CheckHealth(ctx, m.Adapter)
+}
+
+// Close releases the resources of the wrapped adapter
+func (m *mappingAdapter) Close() error {
+	return CloseAdapter(m.Adapter)
+}
+
+// envelope maps the items found at the path, copying the envelope around them
+// so that the upstream result is not changed. Envelopes without the path are
+// returned unchanged.
+func (m *mappingAdapter) envelope(data interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return m.apply(data)
+	}
+
+	source, ok := data.(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+	value, exists := source[path[0]]
+	if !exists {
+		return data, nil
+	}
+
+	mapped, err := m.envelope(value, path[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+	result[path[0]] = mapped
+	return result, nil
+}
+
+// apply maps an upstream item, or each item of a list
+func (m *mappingAdapter) apply(data interface{}) (interface{}, error) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		return m.item(value)
+
+	// the CSV objects of S3 are decoded as a typed list of rows
+	case []map[string]interface{}:
+		items := make([]interface{}, 0, len(value))
+		for i, item := range value {
+			mapped, err := m.item(item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %v", i, err)
+			}
+			items = append(items, mapped)
+		}
+		return items, nil
+
+	case []interface{}:
+		items := make([]interface{}, 0, len(value))
+		for i, item := range value {
+			mapped, err := m.apply(item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %v", i, err)
+			}
+			items = append(items, mapped)
+		}
+		return items, nil
+	}
+	return data, nil
+}
+
+// item builds the result fields from an upstream item
+func (m *mappingAdapter) item(source map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(m.fields))
+	if m.keepUnmapped {
+		for key, value := range source {
+			result[key] = value
+		}
+	}
+
+	for _, field := range m.fields {
+		value := lookupPath(source, field.path)
+		if value == nil {
+			value = field.defaultValue
+		}
+
+		if field.kind != nil {
+			var err error
+			if value, err = field.kind.coerce(value); err != nil {
+				return nil, fmt.Errorf("failed to map field %s: %v", field.name, err)
+			}
+		}
+		result[field.name] = value
+	}
+	return result, nil
+}
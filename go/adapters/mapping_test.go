@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fixedAdapter devolve sempre o mesmo resultado
+type fixedAdapter struct {
+	result interface{}
+	err    error
+}
+
+func (f *fixedAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	return f.result, f.err
+}
+
+func (f *fixedAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return nil, nil
+}
+
+func TestMappingAdapter_GetData(t *testing.T) {
+	convenio := map[string]interface{}{
+		"cd_convenio": "42",
+		"nm_convenio": "Prefeitura",
+		"limites":     map[string]interface{}{"disponivel": 1500.5, "parcelas": []interface{}{"12", "24"}},
+		"ativo":       "true",
+		"situacao":    nil,
+	}
+
+	policy := MappingPolicy{Fields: map[string]MappingField{
+		"codigo":     {Path: "cd_convenio", Type: "Int!"},
+		"nome":       {Path: "/nm_convenio"},
+		"disponivel": {Path: "$.limites.disponivel", Type: "String"},
+		"prazos":     {Path: "$.limites.parcelas", Type: "[Int]"},
+		"ativo":      {Type: "Boolean"},
+		"situacao":   {Default: "ATIVO"},
+		"taxa":       {Path: "$.taxas[0].valor", Default: "1.8", Type: "Float"},
+	}}
+
+	expected := map[string]interface{}{
+		"codigo":     42,
+		"nome":       "Prefeitura",
+		"disponivel": "1500.5",
+		"prazos":     []interface{}{12, 24},
+		"ativo":      true,
+		"situacao":   "ATIVO",
+		"taxa":       1.8,
+	}
+
+	tests := []struct {
+		name     string
+		result   interface{}
+		keep     bool
+		expected interface{}
+	}{
+		{"objeto", convenio, false, expected},
+		{"lista", []interface{}{convenio, convenio}, false, []interface{}{expected, expected}},
+		{"linhas csv", []map[string]interface{}{convenio}, false, []interface{}{expected}},
+		{"escalar", "sem objeto", false, "sem objeto"},
+		{"nulo", nil, false, nil},
+		{"mantém campos não mapeados", map[string]interface{}{"cd_convenio": 7, "uf": "SP"}, true, map[string]interface{}{
+			"cd_convenio": 7, "uf": "SP", "codigo": 7, "nome": nil, "disponivel": nil,
+			"prazos": nil, "ativo": nil, "situacao": "ATIVO", "taxa": 1.8,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy.KeepUnmapped = tt.keep
+			adapter, err := NewMappingAdapter(&fixedAdapter{result: tt.result}, policy)
+			if err != nil {
+				t.Fatalf("NewMappingAdapter() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GetData() = %v, esperado %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMappingAdapter_ItemsPath(t *testing.T) {
+	fields := map[string]MappingField{"codigo": {Path: "cd_convenio", Type: "Int"}}
+	tests := []struct {
+		name      string
+		itemsPath string
+		result    interface{}
+		expected  interface{}
+	}{
+		{"opensearch", "$.hits",
+			map[string]interface{}{"total": 2, "hits": []interface{}{
+				map[string]interface{}{"cd_convenio": "1"},
+				map[string]interface{}{"cd_convenio": "2"},
+			}},
+			map[string]interface{}{"total": 2, "hits": []interface{}{
+				map[string]interface{}{"codigo": 1},
+				map[string]interface{}{"codigo": 2},
+			}}},
+		{"paginação", "/items",
+			map[string]interface{}{"items": []interface{}{map[string]interface{}{"cd_convenio": "7"}}, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+			map[string]interface{}{"items": []interface{}{map[string]interface{}{"codigo": 7}}, "pageInfo": map[string]interface{}{"hasNextPage": false}}},
+		{"sem itens", "$.hits", map[string]interface{}{"total": 0}, map[string]interface{}{"total": 0}},
+		{"nulo", "$.hits", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &fixedAdapter{result: tt.result}
+			adapter, err := NewMappingAdapter(upstream, MappingPolicy{Fields: fields, ItemsPath: tt.itemsPath})
+			if err != nil {
+				t.Fatalf("NewMappingAdapter() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GetData() = %v, esperado %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMappingAdapter_HealthCheck(t *testing.T) {
+	failure := errors.New("dependência indisponível")
+	inner := &healthAdapter{health: failure}
+
+	adapter, _ := NewMappingAdapter(inner, MappingPolicy{Fields: map[string]MappingField{"codigo": {}}})
+	if err := CheckHealth(context.Background(), adapter); !errors.Is(err, failure) {
+		t.Errorf("CheckHealth() erro = %v, esperado %v", err, failure)
+	}
+	if inner.checks != 1 {
+		t.Errorf("verificações = %d, esperado 1", inner.checks)
+	}
+
+	unsupported, _ := NewMappingAdapter(&fixedAdapter{}, MappingPolicy{Fields: map[string]MappingField{"codigo": {}}})
+	if err := CheckHealth(context.Background(), unsupported); !errors.Is(err, ErrHealthCheckUnsupported) {
+		t.Errorf("CheckHealth() erro = %v, esperado ErrHealthCheckUnsupported", err)
+	}
+}
+
+func TestMappingAdapter_Errors(t *testing.T) {
+	failure := errors.New("falha na origem")
+	adapter, _ := NewMappingAdapter(&fixedAdapter{err: failure}, MappingPolicy{Fields: map[string]MappingField{"codigo": {}}})
+	if _, err := adapter.GetData(context.Background(), nil); !errors.Is(err, failure) {
+		t.Errorf("GetData() erro = %v, esperado %v", err, failure)
+	}
+
+	for _, item := range []map[string]interface{}{
+		{"codigo": "quarenta e dois"},
+		{"outro": 1},
+	} {
+		adapter, _ := NewMappingAdapter(&fixedAdapter{result: []interface{}{item}}, MappingPolicy{Fields: map[string]MappingField{
+			"codigo": {Type: "Int!"},
+		}})
+		if _, err := adapter.GetData(context.Background(), nil); err == nil {
+			t.Errorf("GetData(%v) esperado erro de conversão", item)
+		}
+	}
+
+	for _, policy := range []MappingPolicy{
+		{},
+		{Fields: map[string]MappingField{"codigo": {Path: "$["}}},
+		{Fields: map[string]MappingField{"codigo": {Type: "[Int"}}},
+		{Fields: map[string]MappingField{"codigo": {}}, ItemsPath: "$["},
+	} {
+		if _, err := NewMappingAdapter(&fixedAdapter{}, policy); err == nil {
+			t.Errorf("NewMappingAdapter(%v) esperado erro", policy)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func init() {
+	Register("opensearch", newOpenSearchFromSettings)
+}
+
+// Authentication methods supported by the OpenSearch adapter
+const (
+	OpenSearchAuthBasic  = "basic"
+	OpenSearchAuthAPIKey = "apiKey"
+	OpenSearchAuthSigV4  = "sigv4"
+)
+
+type OpenSearchAdapter interface {
+	Adapter
+}
+
+// OpenSearchOptions contains the connection, query and authentication settings
+// of the OpenSearch (or Elasticsearch) adapter
+type OpenSearchOptions struct {
+	// URL is the address of the cluster (e.g. "https://search-catalogo.us-east-1.es.amazonaws.com")
+	URL string `json:"url"`
+
+	// Index is the template of the index, alias or pattern searched
+	Index string `json:"index"`
+
+	// Query is the template of the search request body, in the query DSL
+	Query map[string]interface{} `json:"query"`
+
+	// FromArg and SizeArg are the arguments used to paginate the hits ("from"
+	// and "size" by default). MaxSize limits the page size (100 by default).
+	FromArg string `json:"fromArg"`
+	SizeArg string `json:"sizeArg"`
+	MaxSize int    `json:"maxSize"`
+
+	// Score and Highlight add the "_score" and "_highlight" attributes to the hits
+	Score     bool `json:"score"`
+	Highlight bool `json:"highlight"`
+
+	// Auth is the authentication method: basic, apiKey, sigv4 or none when empty
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	APIKey   string `json:"apiKey"`
+
+	// Region, Service ("es" by default, "aoss" for serverless collections) and the
+	// optional static credentials are used by the sigv4 authentication
+	Region          string `json:"region"`
+	Service         string `json:"service"`
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+
+	// HTTP contains the HTTP client settings, as in the REST adapter
+	HTTP *HTTPClientOptions `json:"http"`
+}
+
+type openSearchAdapter struct {
+	client  *http.Client
+	options OpenSearchOptions
+	signer  *sigV4Signer
+	attr    map[string]interface{}
+}
+
+// NewOpenSearchAdapter creates an adapter that searches an index and returns
+// the hits found along with their total
+func NewOpenSearchAdapter(options OpenSearchOptions, attributes map[string]interface{}) (OpenSearchAdapter, error) {
+	if options.URL == "" || options.Index == "" {
+		return nil, fmt.Errorf("the OpenSearch adapter requires the url and the index")
+	}
+	options.URL = strings.TrimSuffix(options.URL, "/")
+
+	if _, err := ParseTemplate(options.Index); err != nil {
+		return nil, fmt.Errorf("invalid OpenSearch index template: %v", err)
+	}
+	if err := validateTemplate(options.Query); err != nil {
+		return nil, fmt.Errorf("invalid OpenSearch query template: %v", err)
+	}
+
+	if options.FromArg == "" {
+		options.FromArg = "from"
+	}
+	if options.SizeArg == "" {
+		options.SizeArg = "size"
+	}
+	if options.MaxSize <= 0 {
+		options.MaxSize = 100
+	}
+
+	adapter := &openSearchAdapter{
+		client: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+		options: options,
+		attr:    attributes,
+	}
+
+	if options.HTTP != nil {
+		client, err := newHTTPClient(options.URL, *options.HTTP)
+		if err != nil {
+			return nil, err
+		}
+		adapter.client = client
+	}
+
+	switch options.Auth {
+	case "":
+	case OpenSearchAuthBasic:
+		if options.Username == "" {
+			return nil, fmt.Errorf("the basic authentication requires the username")
+		}
+	case OpenSearchAuthAPIKey:
+		if options.APIKey == "" {
+			return nil, fmt.Errorf("the apiKey authentication requires the apiKey")
+		}
+	case OpenSearchAuthSigV4:
+		service := options.Service
+		if service == "" {
+			service = "es"
+		}
+
+		var err error
+		if adapter.signer, err = newSigV4Signer(options.Region, service, options.AccessKeyId, options.SecretAccessKey); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported OpenSearch authentication: %s", options.Auth)
+	}
+
+	return adapter, nil
+}
+
+// newOpenSearchFromSettings creates an OpenSearch adapter from the connector settings
+func newOpenSearchFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("url"); err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("index"); err != nil {
+		return nil, err
+	}
+
+	var options OpenSearchOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewOpenSearchAdapter(options, attributes)
+	return adapter, settings.wrap(err)
+}
+
+func (o *openSearchAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	index, err := renderText(o.options.Index, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenSearch index: %v", err)
+	}
+
+	body, err := o.body(args)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenSearch query: %v", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/_search", o.options.URL, url.PathEscape(index))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenSearch request %s: %v", endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	switch o.options.Auth {
+	case OpenSearchAuthBasic:
+		req.SetBasicAuth(o.options.Username, o.options.Password)
+	case OpenSearchAuthAPIKey:
+		req.Header.Set("Authorization", "ApiKey "+o.options.APIKey)
+	case OpenSearchAuthSigV4:
+		if err := o.signer.sign(ctx, req, content); err != nil {
+			return nil, fmt.Errorf("failed to sign OpenSearch request: %v", err)
+		}
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search OpenSearch index %s: %w", index, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &statusError{code: resp.StatusCode, url: endpoint}
+	}
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenSearch response: %v", err)
+	}
+
+	var result struct {
+		Hits struct {
+			Total json.RawMessage `json:"total"`
+			Hits  []struct {
+				ID        string                 `json:"_id"`
+				Score     *float64               `json:"_score"`
+				Source    map[string]interface{} `json:"_source"`
+				Highlight map[string]interface{} `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenSearch response: %v", err)
+	}
+
+	hits := make([]interface{}, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		item := make(map[string]interface{}, len(hit.Source)+3)
+		for key, value := range hit.Source {
+			item[key] = value
+		}
+		item["_id"] = hit.ID
+		if o.options.Score && hit.Score != nil {
+			item["_score"] = *hit.Score
+		}
+		if o.options.Highlight && hit.Highlight != nil {
+			item["_highlight"] = hit.Highlight
+		}
+		hits = append(hits, item)
+	}
+
+	return map[string]interface{}{
+		"total": totalHits(result.Hits.Total),
+		"hits":  hits,
+	}, nil
+}
+
+// body renders the query template and applies the pagination arguments
+func (o *openSearchAdapter) body(args []AdapterAttribute) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if o.options.Query != nil {
+		rendered, err := renderTemplate(o.options.Query, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build OpenSearch query: %v", err)
+		}
+		body = rendered.(map[string]interface{})
+	}
+
+	for _, arg := range args {
+		if arg.Value == nil {
+			continue
+		}
+
+		switch arg.Name {
+		case o.options.FromArg:
+			from, err := coerceInt(arg.Value)
+			if err != nil || from.(int) < 0 {
+				return nil, &ArgumentError{Name: arg.Name, Type: arg.Type, Reason: "expected a non negative integer"}
+			}
+			body["from"] = from
+		case o.options.SizeArg:
+			size, err := coerceInt(arg.Value)
+			if err != nil || size.(int) < 0 {
+				return nil, &ArgumentError{Name: arg.Name, Type: arg.Type, Reason: "expected a non negative integer"}
+			}
+			body["size"] = size
+		}
+	}
+
+	if value, exists := body["size"]; exists {
+		if size, err := coerceInt(value); err == nil && size.(int) > o.options.MaxSize {
+			body["size"] = o.options.MaxSize
+		}
+	}
+	return body, nil
+}
+
+// totalHits reads the total of hits, informed as a number by older versions
+// and as {"value": n, "relation": "eq"} by the current ones
+func totalHits(raw json.RawMessage) int64 {
+	var total struct {
+		Value int64 `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &total); err == nil {
+		return total.Value
+	}
+
+	var value int64
+	json.Unmarshal(raw, &value)
+	return value
+}
+
+func (o *openSearchAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return getParameters(o.attr, args)
+}
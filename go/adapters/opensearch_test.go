@@ -0,0 +1,165 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newOpenSearchStandIn(t *testing.T, check func(r *http.Request, body map[string]interface{})) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/catalogo-consignado/_search" {
+			t.Errorf("requisição = %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		check(r, body)
+
+		w.Write([]byte(`{
+			"hits": {
+				"total": {"value": 2, "relation": "eq"},
+				"hits": [
+					{"_id": "1", "_score": 1.5, "_source": {"nome": "Consignado INSS"}, "highlight": {"nome": ["<em>INSS</em>"]}},
+					{"_id": "2", "_score": 0.7, "_source": {"nome": "Consignado Privado"}}
+				]
+			}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenSearchAdapter_Search(t *testing.T) {
+	var received map[string]interface{}
+	server := newOpenSearchStandIn(t, func(r *http.Request, body map[string]interface{}) {
+		received = body
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			t.Errorf("BasicAuth = %s:%s", user, pass)
+		}
+	})
+
+	adapter, err := NewOpenSearchAdapter(OpenSearchOptions{
+		URL:   server.URL + "/",
+		Index: "catalogo-{produto}",
+		Query: map[string]interface{}{
+			"query": map[string]interface{}{"match": map[string]interface{}{"nome": "{termo}"}},
+		},
+		MaxSize:   20,
+		Score:     true,
+		Highlight: true,
+		Auth:      OpenSearchAuthBasic,
+		Username:  "admin",
+		Password:  "secret",
+	}, map[string]interface{}{"produto": "String!", "termo": "String!", "from": "Int", "size": "Int"})
+	if err != nil {
+		t.Fatalf("NewOpenSearchAdapter() erro = %v", err)
+	}
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"produto": "consignado", "termo": "inss", "from": 10, "size": 50})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if received["from"] != 10.0 || received["size"] != 20.0 {
+		t.Errorf("paginação = from %v size %v, esperado 10 e 20", received["from"], received["size"])
+	}
+
+	expected := map[string]interface{}{
+		"total": int64(2),
+		"hits": []interface{}{
+			map[string]interface{}{"_id": "1", "nome": "Consignado INSS", "_score": 1.5, "_highlight": map[string]interface{}{"nome": []interface{}{"<em>INSS</em>"}}},
+			map[string]interface{}{"_id": "2", "nome": "Consignado Privado", "_score": 0.7},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetData() = %v, esperado %v", result, expected)
+	}
+}
+
+func TestOpenSearchAdapter_Auth(t *testing.T) {
+	tests := []struct {
+		name    string
+		options OpenSearchOptions
+		check   func(t *testing.T, r *http.Request)
+	}{
+		{
+			name:    "api key",
+			options: OpenSearchOptions{Auth: OpenSearchAuthAPIKey, APIKey: "chave"},
+			check: func(t *testing.T, r *http.Request) {
+				if r.Header.Get("Authorization") != "ApiKey chave" {
+					t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+				}
+			},
+		},
+		{
+			name:    "sigv4",
+			options: OpenSearchOptions{Auth: OpenSearchAuthSigV4, Region: "us-east-1", AccessKeyId: "AKID", SecretAccessKey: "SECRET"},
+			check: func(t *testing.T, r *http.Request) {
+				auth := r.Header.Get("Authorization")
+				if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/es/aws4_request") {
+					t.Errorf("Authorization = %q", auth)
+				}
+				if r.Header.Get("X-Amz-Content-Sha256") == "" || r.Header.Get("X-Amz-Date") == "" {
+					t.Errorf("cabeçalhos SigV4 ausentes")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newOpenSearchStandIn(t, func(r *http.Request, body map[string]interface{}) {
+				tt.check(t, r)
+			})
+
+			tt.options.URL = server.URL
+			tt.options.Index = "catalogo-consignado"
+			adapter, err := NewOpenSearchAdapter(tt.options, nil)
+			if err != nil {
+				t.Fatalf("NewOpenSearchAdapter() erro = %v", err)
+			}
+
+			if _, err := adapter.GetData(context.Background(), nil); err != nil {
+				t.Errorf("GetData() erro = %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenSearchAdapter_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	adapter, _ := NewOpenSearchAdapter(OpenSearchOptions{URL: server.URL, Index: "catalogo"}, nil)
+	_, err := adapter.GetData(context.Background(), nil)
+
+	var status interface{ Status() int }
+	if !errors.As(err, &status) || status.Status() != http.StatusServiceUnavailable {
+		t.Errorf("GetData() erro = %v, esperado status 503", err)
+	}
+
+	if _, err := adapter.GetData(context.Background(), []AdapterAttribute{{Name: "size", Type: "Int", Value: -1}}); err == nil {
+		t.Errorf("GetData() esperado erro para size negativo")
+	}
+
+	for _, raw := range []map[string]interface{}{
+		{"index": "catalogo"},
+		{"url": server.URL},
+		{"url": server.URL, "index": "catalogo", "auth": "token"},
+		{"url": server.URL, "index": "catalogo", "auth": "basic"},
+	} {
+		var configErr *ConfigError
+		if _, err := New("opensearch", Settings{Raw: raw}); !errors.As(err, &configErr) {
+			t.Errorf("New(%v) erro = %v, esperado *ConfigError", raw, err)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package adapters
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination strategies supported by the REST adapter
+const (
+	PaginationCursor = "cursor"
+	PaginationLink   = "link"
+	PaginationPage   = "page"
+)
+
+// Pagination modes of the REST adapter
+const (
+	PaginationAll         = "all"
+	PaginationPassthrough = "passthrough"
+)
+
+// RestPagination contains the settings used to read an upstream that pages its results
+type RestPagination struct {
+	// Strategy is how the upstream pages its results: cursor (a token read from
+	// the response), link (the rel="next" Link header) or page (page numbers)
+	Strategy string `json:"strategy"`
+
+	// Mode is "all" to follow the pages and concatenate their items, or
+	// "passthrough" to fetch the single page selected by the first and after
+	// arguments, returning its items along with the page info
+	Mode string `json:"mode"`
+
+	// ItemsPath selects the list of items of each page. When empty, the value
+	// selected by the response settings is used.
+	ItemsPath string `json:"itemsPath"`
+
+	// CursorParam is the query parameter of the cursor ("cursor" by default) and
+	// CursorPath selects the next cursor in the response (e.g. "$.meta.next")
+	CursorParam string `json:"cursorParam"`
+	CursorPath  string `json:"cursorPath"`
+
+	// PageParam is the query parameter of the page number ("page" by default)
+	// and FirstPage is the number of the first page (1 by default)
+	PageParam string `json:"pageParam"`
+	FirstPage *int   `json:"firstPage"`
+
+	// SizeParam is the query parameter of the page size ("size" by default),
+	// sent when PageSize or the first argument is informed
+	SizeParam string `json:"sizeParam"`
+	PageSize  int    `json:"pageSize"`
+
+	// MaxPages and MaxItems limit how much is read in the "all" mode (10 pages
+	// by default and no limit of items)
+	MaxPages int `json:"maxPages"`
+	MaxItems int `json:"maxItems"`
+
+	// FirstArg and AfterArg are the arguments read in the "passthrough" mode
+	// ("first" and "after" by default), which must be declared in the attr
+	FirstArg string `json:"firstArg"`
+	AfterArg string `json:"afterArg"`
+}
+
+// restPage is a page read from the upstream
+type restPage struct {
+	items   []interface{}
+	next    string
+	hasNext bool
+}
+
+func (r *restAdapter) WithPagination(pagination RestPagination) (RestAdapter, error) {
+	switch pagination.Strategy {
+	case PaginationCursor:
+		if pagination.CursorPath == "" {
+			return nil, fmt.Errorf("the cursor pagination requires the cursor path")
+		}
+		path, err := compilePath(pagination.CursorPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pagination cursor path: %v", err)
+		}
+		r.cursorPath = path
+	case PaginationLink, PaginationPage:
+	default:
+		return nil, fmt.Errorf("unsupported pagination strategy: %s", pagination.Strategy)
+	}
+
+	switch pagination.Mode {
+	case "":
+		pagination.Mode = PaginationAll
+	case PaginationAll, PaginationPassthrough:
+	default:
+		return nil, fmt.Errorf("unsupported pagination mode: %s", pagination.Mode)
+	}
+
+	if pagination.ItemsPath != "" {
+		path, err := compilePath(pagination.ItemsPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pagination items path: %v", err)
+		}
+		r.itemsPath = path
+	}
+
+	if pagination.CursorParam == "" {
+		pagination.CursorParam = "cursor"
+	}
+	if pagination.PageParam == "" {
+		pagination.PageParam = "page"
+	}
+	if pagination.FirstPage == nil {
+		first := 1
+		pagination.FirstPage = &first
+	}
+	if pagination.SizeParam == "" {
+		pagination.SizeParam = "size"
+	}
+	if pagination.MaxPages <= 0 {
+		pagination.MaxPages = 10
+	}
+	if pagination.FirstArg == "" {
+		pagination.FirstArg = "first"
+	}
+	if pagination.AfterArg == "" {
+		pagination.AfterArg = "after"
+	}
+
+	r.pagination = &pagination
+	return r, nil
+}
+
+// paginate reads the pages of the upstream according to the pagination mode
+func (r *restAdapter) paginate(ctx context.Context, args []AdapterAttribute, body interface{}) (interface{}, error) {
+	base, err := r.url(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.pagination.Mode == PaginationPassthrough {
+		return r.passthrough(ctx, args, base, body)
+	}
+
+	items := make([]interface{}, 0)
+	cursor := ""
+	for pages := 0; pages < r.pagination.MaxPages; pages++ {
+		page, err := r.page(ctx, args, base, body, cursor, r.pagination.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.items...)
+		if r.pagination.MaxItems > 0 && len(items) >= r.pagination.MaxItems {
+			return items[:r.pagination.MaxItems], nil
+		}
+		if !page.hasNext {
+			break
+		}
+		cursor = page.next
+	}
+	return items, nil
+}
+
+// passthrough reads the page selected by the first and after arguments
+func (r *restAdapter) passthrough(ctx context.Context, args []AdapterAttribute, base string, body interface{}) (interface{}, error) {
+	size := r.pagination.PageSize
+	cursor := ""
+
+	for _, arg := range args {
+		if arg.Value == nil {
+			continue
+		}
+
+		switch arg.Name {
+		case r.pagination.FirstArg:
+			first, err := coerceInt(arg.Value)
+			if err != nil || first.(int) <= 0 {
+				return nil, &ArgumentError{Name: arg.Name, Type: arg.Type, Reason: "expected a positive integer"}
+			}
+			size = first.(int)
+
+		case r.pagination.AfterArg:
+			after := fmt.Sprintf("%v", arg.Value)
+			next, err := r.decodeCursor(after, base)
+			if err != nil {
+				return nil, &ArgumentError{Name: arg.Name, Type: arg.Type, Reason: err.Error()}
+			}
+			cursor = next
+		}
+	}
+
+	page, err := r.page(ctx, args, base, body, cursor, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var endCursor interface{}
+	if page.hasNext {
+		endCursor = r.encodeCursor(page.next)
+	}
+
+	return map[string]interface{}{
+		"items": page.items,
+		"pageInfo": map[string]interface{}{
+			"hasNextPage": page.hasNext,
+			"endCursor":   endCursor,
+		},
+	}, nil
+}
+
+// page reads the page identified by the cursor, which is empty for the first
+// page, the page number or the next link, according to the strategy
+func (r *restAdapter) page(ctx context.Context, args []AdapterAttribute, base string, body interface{}, cursor string, size int) (restPage, error) {
+	var (
+		page   restPage
+		target = base
+		query  = url.Values{}
+	)
+
+	switch r.pagination.Strategy {
+	case PaginationCursor:
+		if cursor != "" {
+			query.Set(r.pagination.CursorParam, cursor)
+		}
+	case PaginationLink:
+		// the next link already carries every parameter of the page
+		if cursor != "" {
+			target = cursor
+			size = 0
+		}
+	case PaginationPage:
+		if cursor == "" {
+			cursor = strconv.Itoa(*r.pagination.FirstPage)
+		}
+		query.Set(r.pagination.PageParam, cursor)
+	}
+	if size > 0 {
+		query.Set(r.pagination.SizeParam, strconv.Itoa(size))
+	}
+
+	target, err := withQuery(target, query)
+	if err != nil {
+		return page, fmt.Errorf("failed to build REST API page %s: %v", target, err)
+	}
+
+	data, header, err := r.send(ctx, args, target, body)
+	if err != nil || data == nil {
+		return page, err
+	}
+
+	var items interface{}
+	if r.itemsPath != nil {
+		items = lookupPath(data, r.itemsPath)
+	} else {
+		items = r.extract(data)
+	}
+
+	switch list := items.(type) {
+	case nil:
+	case []interface{}:
+		page.items = list
+	default:
+		return page, fmt.Errorf("the REST API page %s does not contain a list of items", target)
+	}
+
+	switch r.pagination.Strategy {
+	case PaginationCursor:
+		if next := lookupPath(data, r.cursorPath); next != nil {
+			page.next = fmt.Sprintf("%v", next)
+		}
+		page.hasNext = page.next != ""
+	case PaginationLink:
+		// the upstream must not direct the request, and its credentials, to another host
+		page.next = nextLink(header, target)
+		if page.next != "" && !sameOrigin(page.next, base) {
+			return page, fmt.Errorf("the next link of the REST API page %s points to another host", target)
+		}
+		page.hasNext = page.next != ""
+	case PaginationPage:
+		number, _ := strconv.Atoi(cursor)
+		page.next = strconv.Itoa(number + 1)
+		page.hasNext = len(page.items) > 0 && (size <= 0 || len(page.items) >= size)
+	}
+	return page, nil
+}
+
+// encodeCursor returns the cursor exposed to the clients. Links are encoded,
+// so that the cursor remains opaque.
+func (r *restAdapter) encodeCursor(next string) string {
+	if r.pagination.Strategy == PaginationLink {
+		return base64.RawURLEncoding.EncodeToString([]byte(next))
+	}
+	return next
+}
+
+// decodeCursor reads a cursor informed by a client. Links must point to the
+// same host as the adapter, since the client could otherwise direct the
+// request, and its credentials, to any address.
+func (r *restAdapter) decodeCursor(cursor, base string) (string, error) {
+	switch r.pagination.Strategy {
+	case PaginationPage:
+		if _, err := strconv.Atoi(cursor); err != nil {
+			return "", fmt.Errorf("invalid page cursor")
+		}
+
+	case PaginationLink:
+		content, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return "", fmt.Errorf("invalid page cursor")
+		}
+
+		link, err := url.Parse(string(content))
+		if err != nil || !sameOrigin(link.String(), base) {
+			return "", fmt.Errorf("invalid page cursor")
+		}
+		return link.String(), nil
+	}
+	return cursor, nil
+}
+
+// sameOrigin reports whether the address has the scheme and host of the base address
+func sameOrigin(address, base string) bool {
+	link, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+	origin, err := url.Parse(base)
+	return err == nil && link.Scheme == origin.Scheme && link.Host == origin.Host
+}
+
+// withQuery adds the query parameters to the address
+func withQuery(address string, query url.Values) (string, error) {
+	if len(query) == 0 {
+		return address, nil
+	}
+
+	target, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+
+	values := target.Query()
+	for key := range query {
+		values.Set(key, query.Get(key))
+	}
+	target.RawQuery = values.Encode()
+	return target.String(), nil
+}
+
+// nextLink returns the rel="next" address of the Link header, resolved
+// against the address of the current page
+func nextLink(header http.Header, current string) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			address := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(address, "<") || !strings.HasSuffix(address, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if name != "rel" || !containsRel(strings.Trim(rel, `"`), "next") {
+					continue
+				}
+
+				base, err := url.Parse(current)
+				if err != nil {
+					return ""
+				}
+				next, err := base.Parse(strings.Trim(address, "<>"))
+				if err != nil {
+					return ""
+				}
+				return next.String()
+			}
+		}
+	}
+	return ""
+}
+
+// containsRel reports whether the space separated relation types contain the one informed
+func containsRel(rels, rel string) bool {
+	for _, value := range strings.Fields(rels) {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package adapters
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+// parcelas simula uma lista de parcelas com os códigos de 1 a 5
+var parcelas = []interface{}{1.0, 2.0, 3.0, 4.0, 5.0}
+
+func newPagedAdapter(t *testing.T, handler http.HandlerFunc, pagination RestPagination, attributes map[string]interface{}) (RestAdapter, *[]string) {
+	requests := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	adapter, err := NewRestAdapter(&types.Config{}, server.URL, "parcelas", false, attributes, nil).
+		WithPagination(pagination)
+	if err != nil {
+		t.Fatalf("WithPagination() erro = %v", err)
+	}
+	return adapter, &requests
+}
+
+// cursorHandler devolve duas parcelas por página, com o cursor da próxima em meta.next
+func cursorHandler(w http.ResponseWriter, r *http.Request) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	end := min(start+2, len(parcelas))
+
+	next := ""
+	if end < len(parcelas) {
+		next = strconv.Itoa(end)
+	}
+	fmt.Fprintf(w, `{"data": %s, "meta": {"next": %q}}`, toJSON(parcelas[start:end]), next)
+}
+
+func toJSON(items []interface{}) string {
+	content, _ := json.Marshal(items)
+	return string(content)
+}
+
+func TestRestAdapter_Pagination_All(t *testing.T) {
+	linkHandler := func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`</parcelas?p=%d>; rel="next", </parcelas?p=0>; rel="first"`, page+1))
+		}
+		fmt.Fprint(w, toJSON(parcelas[page*2:min(page*2+2, len(parcelas))]))
+	}
+
+	pageHandler := func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("pagina"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		start := min(page*size, len(parcelas))
+		fmt.Fprintf(w, `{"resultado": {"itens": %s}}`, toJSON(parcelas[start:min(start+size, len(parcelas))]))
+	}
+
+	zero := 0
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		pagination RestPagination
+		expected   []interface{}
+		requests   []string
+	}{
+		{"cursor", cursorHandler, RestPagination{Strategy: PaginationCursor, CursorPath: "$.meta.next"},
+			parcelas, []string{"", "cursor=2", "cursor=4"}},
+		{"cursor com limite de itens", cursorHandler, RestPagination{Strategy: PaginationCursor, CursorPath: "$.meta.next", MaxItems: 3},
+			parcelas[:3], []string{"", "cursor=2"}},
+		{"cursor com limite de páginas", cursorHandler, RestPagination{Strategy: PaginationCursor, CursorPath: "$.meta.next", MaxPages: 1},
+			parcelas[:2], []string{""}},
+		{"link", linkHandler, RestPagination{Strategy: PaginationLink},
+			parcelas, []string{"", "p=1", "p=2"}},
+		{"página", pageHandler, RestPagination{Strategy: PaginationPage, PageParam: "pagina", FirstPage: &zero, PageSize: 2, ItemsPath: "$.resultado.itens"},
+			parcelas, []string{"pagina=0&size=2", "pagina=1&size=2", "pagina=2&size=2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, requests := newPagedAdapter(t, tt.handler, tt.pagination, nil)
+
+			result, err := adapter.GetData(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GetData() = %v, esperado %v", result, tt.expected)
+			}
+			if !reflect.DeepEqual(*requests, tt.requests) {
+				t.Errorf("requisições = %q, esperado %q", *requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestRestAdapter_Pagination_Passthrough(t *testing.T) {
+	attributes := map[string]interface{}{"first": "Int", "after": "String"}
+	adapter, requests := newPagedAdapter(t, cursorHandler, RestPagination{
+		Strategy:   PaginationCursor,
+		Mode:       PaginationPassthrough,
+		CursorPath: "/meta/next",
+	}, attributes)
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"first": 2, "after": "2"})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"items":    parcelas[2:4],
+		"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "4"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetData() = %v, esperado %v", result, expected)
+	}
+	if (*requests)[0] != "cursor=2&size=2" {
+		t.Errorf("requisição = %q, esperado cursor=2&size=2", (*requests)[0])
+	}
+
+	params, _ = adapter.GetParameters(map[string]interface{}{"first": 2, "after": "4"})
+	result, _ = adapter.GetData(context.Background(), params)
+	if info := result.(map[string]interface{})["pageInfo"]; !reflect.DeepEqual(info, map[string]interface{}{"hasNextPage": false, "endCursor": nil}) {
+		t.Errorf("pageInfo = %v, esperado última página", info)
+	}
+
+	var argErr *ArgumentError
+	params, _ = adapter.GetParameters(map[string]interface{}{"first": 0})
+	if _, err := adapter.GetData(context.Background(), params); !errors.As(err, &argErr) {
+		t.Errorf("GetData() erro = %v, esperado *ArgumentError para first 0", err)
+	}
+}
+
+func TestRestAdapter_Pagination_LinkCursor(t *testing.T) {
+	attributes := map[string]interface{}{"first": "Int", "after": "String"}
+	adapter, requests := newPagedAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `</parcelas?p=2&size=2>; rel="next"`)
+		fmt.Fprint(w, `[1, 2]`)
+	}, RestPagination{Strategy: PaginationLink, Mode: PaginationPassthrough}, attributes)
+
+	params, _ := adapter.GetParameters(map[string]interface{}{"first": 2})
+	result, err := adapter.GetData(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	cursor, _ := result.(map[string]interface{})["pageInfo"].(map[string]interface{})["endCursor"].(string)
+	params, _ = adapter.GetParameters(map[string]interface{}{"first": 2, "after": cursor})
+	if _, err := adapter.GetData(context.Background(), params); err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+	if !reflect.DeepEqual(*requests, []string{"size=2", "p=2&size=2"}) {
+		t.Errorf("requisições = %q", *requests)
+	}
+
+	// um cursor não pode direcionar a requisição para outro host
+	foreign := base64.RawURLEncoding.EncodeToString([]byte("http://169.254.169.254/latest/meta-data"))
+	var argErr *ArgumentError
+	params, _ = adapter.GetParameters(map[string]interface{}{"after": foreign})
+	if _, err := adapter.GetData(context.Background(), params); !errors.As(err, &argErr) {
+		t.Errorf("GetData() erro = %v, esperado *ArgumentError", err)
+	}
+}
+
+func TestRestAdapter_Pagination_ForeignLink(t *testing.T) {
+	adapter, requests := newPagedAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<http://169.254.169.254/latest/meta-data>; rel="next"`)
+		fmt.Fprint(w, `[1, 2]`)
+	}, RestPagination{Strategy: PaginationLink}, nil)
+
+	// o link de outro host não é seguido, já que levaria as credenciais do adaptador
+	if _, err := adapter.GetData(context.Background(), nil); err == nil {
+		t.Errorf("GetData() esperado erro para o link de outro host")
+	}
+	if len(*requests) != 1 {
+		t.Errorf("requisições = %q, esperado apenas a primeira página", *requests)
+	}
+}
+
+func TestRestAdapter_Pagination_InvalidConfig(t *testing.T) {
+	for _, pagination := range []map[string]interface{}{
+		{"strategy": "offset"},
+		{"strategy": "cursor"},
+		{"strategy": "cursor", "cursorPath": "$["},
+		{"strategy": "page", "mode": "stream"},
+		{"strategy": "page", "itemsPath": "$["},
+	} {
+		var configErr *ConfigError
+		_, err := New("rest", Settings{Raw: map[string]interface{}{"baseUrl": "http://localhost", "pagination": pagination}})
+		if !errors.As(err, &configErr) {
+			t.Errorf("New(%v) erro = %v, esperado *ConfigError", pagination, err)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/raywall/cloud-service-pack/go/data"
+	"github.com/raywall/cloud-service-pack/go/data/aws/connector"
+)
+
+func init() {
+	Register("parameter", newParameterFromSettings)
+}
+
+// Stores read by the parameter adapter
+const (
+	ParameterSourceSSM       = "ssm"
+	ParameterSourceSecrets   = "secrets"
+	ParameterSourceAppConfig = "appconfig"
+)
+
+// Value formats decoded by the parameter adapter
+const (
+	ParameterFormatText = "text"
+	ParameterFormatJSON = "json"
+	ParameterFormatYAML = "yaml"
+	ParameterFormatCSV  = "csv"
+)
+
+type ParameterAdapter interface {
+	Adapter
+}
+
+// ParameterOptions contains the settings of the adapter that exposes values of
+// the SSM Parameter Store, Secrets Manager or AppConfig
+type ParameterOptions struct {
+	// Source is the store read: ssm, secrets or appconfig
+	Source string `json:"source"`
+
+	// Name is the template of the parameter name, secret id or, for AppConfig,
+	// configuration profile (e.g. "/consignado/{produto}/limites")
+	Name string `json:"name"`
+
+	// Decrypt reads SecureString parameters decrypted
+	Decrypt bool `json:"decrypt"`
+
+	// Application and Environment identify the AppConfig deployment
+	Application string `json:"application"`
+	Environment string `json:"environment"`
+
+	// Format is the format of the value (text, json, yaml or csv). Text values
+	// are returned as strings.
+	Format string `json:"format"`
+
+	// ResponsePath selects the value returned from the decoded content
+	ResponsePath string `json:"responsePath"`
+
+	// NotFoundAsNull indicates that a missing parameter results in a null value
+	NotFoundAsNull bool `json:"notFoundAsNull"`
+
+	// Region, Endpoint and the optional static credentials configure a session
+	// of its own instead of the session of the GraphQL API
+	Region          string `json:"region"`
+	Endpoint        string `json:"endpoint"`
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
+type parameterAdapter struct {
+	fetch   func(ctx context.Context, name string) (*string, error)
+	options ParameterOptions
+	path    []string
+	attr    map[string]interface{}
+}
+
+// NewParameterAdapter creates an adapter that reads a value through the cloud
+// contexts of the data package. A new session is created when sess is nil or
+// when the options inform a region, endpoint or credentials.
+func NewParameterAdapter(sess *session.Session, options ParameterOptions, attributes map[string]interface{}) (ParameterAdapter, error) {
+	if options.Name == "" {
+		return nil, fmt.Errorf("the parameter adapter requires the parameter name")
+	}
+	if _, err := ParseTemplate(options.Name); err != nil {
+		return nil, fmt.Errorf("invalid parameter name template: %v", err)
+	}
+
+	options.Format = strings.ToLower(options.Format)
+	switch options.Format {
+	case "":
+		options.Format = ParameterFormatText
+	case ParameterFormatText, ParameterFormatJSON, ParameterFormatYAML, ParameterFormatCSV:
+	default:
+		return nil, fmt.Errorf("unsupported parameter format: %s", options.Format)
+	}
+
+	adapter := &parameterAdapter{options: options, attr: attributes}
+	if options.ResponsePath != "" {
+		path, err := compilePath(options.ResponsePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter response path: %v", err)
+		}
+		adapter.path = path
+	}
+
+	if sess == nil || options.Region != "" || options.Endpoint != "" || options.AccessKeyId != "" {
+		cfg := aws.NewConfig()
+		if options.Region != "" {
+			cfg.WithRegion(options.Region)
+		}
+		if options.Endpoint != "" {
+			cfg.WithEndpoint(options.Endpoint)
+		}
+		if options.AccessKeyId != "" {
+			cfg.WithCredentials(credentials.NewStaticCredentials(options.AccessKeyId, options.SecretAccessKey, ""))
+		}
+
+		var err error
+		if sess, err = session.NewSession(cfg); err != nil {
+			return nil, fmt.Errorf("failed to create AWS session: %v", err)
+		}
+	}
+
+	switch options.Source {
+	case ParameterSourceSSM:
+		store := connector.NewSSMContext(sess)
+		adapter.fetch = func(ctx context.Context, name string) (*string, error) {
+			return store.GetValueWithContext(ctx, name, options.Decrypt)
+		}
+	case ParameterSourceSecrets:
+		store := connector.NewSecretsManagerContext(sess)
+		adapter.fetch = func(ctx context.Context, name string) (*string, error) {
+			return store.GetValueWithContext(ctx, name, options.Format)
+		}
+	case ParameterSourceAppConfig:
+		if options.Application == "" || options.Environment == "" {
+			return nil, fmt.Errorf("the appconfig source requires the application and the environment")
+		}
+		store := connector.NewAppConfigContext(sess)
+		adapter.fetch = func(ctx context.Context, name string) (*string, error) {
+			return store.GetValueWithContext(ctx, options.Application, options.Environment, name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported parameter source: %s", options.Source)
+	}
+
+	return adapter, nil
+}
+
+// newParameterFromSettings creates a parameter adapter from the connector settings
+func newParameterFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := settings.RequiredString("source"); err != nil {
+		return nil, err
+	}
+
+	var options ParameterOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	// the parameter name can be informed as a template in the adapter settings
+	if options.Name == "" {
+		options.Name = settings.KeyPattern
+	}
+
+	var sess *session.Session
+	if settings.Config != nil {
+		sess = settings.Config.Session
+	}
+
+	adapter, err := NewParameterAdapter(sess, options, attributes)
+	return adapter, settings.wrap(err)
+}
+
+func (p *parameterAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	name, err := renderText(p.options.Name, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the parameter name: %v", err)
+	}
+
+	value, err := p.fetch(ctx, name)
+	if err != nil {
+		if p.options.NotFoundAsNull && isParameterNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read parameter %s: %w", name, err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	if p.options.Format == ParameterFormatText {
+		return *value, nil
+	}
+
+	content := data.String(*value)
+	decoded, err := content.CastTo(data.ContentType(p.options.Format))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode parameter %s: %v", name, err)
+	}
+
+	if p.path != nil {
+		return lookupPath(decoded, p.path), nil
+	}
+	return decoded, nil
+}
+
+// isParameterNotFound reports whether the store informed that the parameter does not exist
+func isParameterNotFound(err error) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+
+	switch awsErr.Code() {
+	case "ParameterNotFound", "ResourceNotFoundException":
+		return true
+	}
+	return false
+}
+
+func (p *parameterAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return getParameters(p.attr, args)
+}
@@ -0,0 +1,180 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// newParameterStoreEmulator simula as APIs do SSM, Secrets Manager e AppConfig
+func newParameterStoreEmulator(t *testing.T, values map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&input)
+
+		var (
+			name   string
+			output func(value string) interface{}
+		)
+
+		switch {
+		case r.Header.Get("X-Amz-Target") == "AmazonSSM.GetParameter":
+			name, _ = input["Name"].(string)
+			if input["WithDecryption"] != true {
+				t.Errorf("WithDecryption = %v, esperado true", input["WithDecryption"])
+			}
+			output = func(value string) interface{} {
+				return map[string]interface{}{"Parameter": map[string]interface{}{"Name": name, "Value": value}}
+			}
+
+		case r.Header.Get("X-Amz-Target") == "secretsmanager.GetSecretValue":
+			name, _ = input["SecretId"].(string)
+			output = func(value string) interface{} {
+				return map[string]interface{}{"Name": name, "SecretString": value}
+			}
+
+		case r.URL.Path == "/configurationsessions":
+			name, _ = input["ConfigurationProfileIdentifier"].(string)
+			output = func(value string) interface{} {
+				return map[string]interface{}{"InitialConfigurationToken": name}
+			}
+
+		case r.URL.Path == "/configuration":
+			name = r.URL.Query().Get("configuration_token")
+			if value, exists := values[name]; exists {
+				w.Header().Set("Next-Poll-Configuration-Token", name)
+				w.Write([]byte(value))
+				return
+			}
+
+		default:
+			t.Errorf("requisição inesperada %s %s", r.Method, r.URL.Path)
+		}
+
+		value, exists := values[name]
+		if !exists {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type": "ParameterNotFound", "message": "not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(output(value))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParameterAdapter_Sources(t *testing.T) {
+	server := newParameterStoreEmulator(t, map[string]string{
+		"/consignado/inss/taxa": "1.8",
+		"consignado/inss":       `{"taxa": 1.8, "limites": {"parcelas": 84}}`,
+		"limites":               "parcelas: 84\nmargem: 35\n",
+	})
+
+	tests := []struct {
+		name     string
+		options  ParameterOptions
+		expected interface{}
+	}{
+		{"ssm texto", ParameterOptions{Source: ParameterSourceSSM, Name: "/consignado/{produto}/taxa", Decrypt: true}, "1.8"},
+		{"secrets json", ParameterOptions{Source: ParameterSourceSecrets, Name: "consignado/{produto}", Format: "JSON", ResponsePath: "$.limites.parcelas"}, 84.0},
+		{"appconfig yaml", ParameterOptions{Source: ParameterSourceAppConfig, Name: "limites", Application: "consignado", Environment: "prod", Format: ParameterFormatYAML}, map[string]interface{}{"parcelas": 84, "margem": 35}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Region = "us-east-1"
+			tt.options.Endpoint = server.URL
+			tt.options.AccessKeyId = "test"
+			tt.options.SecretAccessKey = "test"
+
+			adapter, err := NewParameterAdapter(nil, tt.options, map[string]interface{}{"produto": "String"})
+			if err != nil {
+				t.Fatalf("NewParameterAdapter() erro = %v", err)
+			}
+
+			params, _ := adapter.GetParameters(map[string]interface{}{"produto": "inss"})
+			result, err := adapter.GetData(context.Background(), params)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GetData() = %#v, esperado %#v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParameterAdapter_NotFound(t *testing.T) {
+	server := newParameterStoreEmulator(t, nil)
+
+	options := ParameterOptions{
+		Source:          ParameterSourceSSM,
+		Name:            "/consignado/{produto}/taxa",
+		Decrypt:         true,
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		AccessKeyId:     "test",
+		SecretAccessKey: "test",
+	}
+	args := []AdapterAttribute{{Name: "produto", Type: "String", Value: "fgts"}}
+
+	adapter, _ := NewParameterAdapter(nil, options, nil)
+	if _, err := adapter.GetData(context.Background(), args); err == nil {
+		t.Errorf("GetData() esperado erro para parâmetro inexistente")
+	}
+
+	options.NotFoundAsNull = true
+	adapter, _ = NewParameterAdapter(nil, options, nil)
+	if result, err := adapter.GetData(context.Background(), args); err != nil || result != nil {
+		t.Errorf("GetData() = %v, erro = %v, esperado nil", result, err)
+	}
+}
+
+func TestParameterAdapter_Canceled(t *testing.T) {
+	server := newParameterStoreEmulator(t, map[string]string{"/consignado/inss/taxa": "1.8"})
+
+	adapter, _ := NewParameterAdapter(nil, ParameterOptions{
+		Source:          ParameterSourceSSM,
+		Name:            "/consignado/{produto}/taxa",
+		Decrypt:         true,
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		AccessKeyId:     "test",
+		SecretAccessKey: "test",
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	args := []AdapterAttribute{{Name: "produto", Type: "String", Value: "inss"}}
+	var awsErr awserr.Error
+	if _, err := adapter.GetData(ctx, args); !errors.As(err, &awsErr) || awsErr.Code() != request.CanceledErrorCode {
+		t.Errorf("GetData() erro = %v, esperado %s", err, request.CanceledErrorCode)
+	}
+}
+
+func TestParameterAdapter_InvalidConfig(t *testing.T) {
+	for _, raw := range []map[string]interface{}{
+		{"name": "/taxa"},
+		{"source": "ssm"},
+		{"source": "parameterstore", "name": "/taxa"},
+		{"source": "ssm", "name": "/taxa", "format": "xml"},
+		{"source": "appconfig", "name": "limites"},
+		{"source": "ssm", "name": "/taxa/{produto|reverse}"},
+	} {
+		raw["region"] = "us-east-1"
+
+		var configErr *ConfigError
+		if _, err := New("parameter", Settings{Raw: raw}); !errors.As(err, &configErr) {
+			t.Errorf("New(%v) erro = %v, esperado *ConfigError", raw, err)
+		}
+	}
+}
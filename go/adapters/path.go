@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var pathSegment *regexp.Regexp = regexp.MustCompile(`^(?:\.?([^.\[\]]+)|\[(\d+)\]|\['([^']*)'\])`)
+
+// compilePath splits a JSON pointer (e.g. "/data/items/0") or a JSONPath
+// expression (e.g. "$.data.items[0]" or "data.items") into its segments.
+// The root path ("$" or "") is represented by an empty list of segments.
+func compilePath(path string) ([]string, error) {
+	segments := make([]string, 0)
+
+	switch {
+	case path == "" || path == "$":
+		return segments, nil
+
+	case strings.HasPrefix(path, "/"):
+		for _, segment := range strings.Split(path[1:], "/") {
+			segment = strings.ReplaceAll(segment, "~1", "/")
+			segments = append(segments, strings.ReplaceAll(segment, "~0", "~"))
+		}
+		return segments, nil
+	}
+
+	expr := strings.TrimPrefix(path, "$")
+	for expr != "" {
+		match := pathSegment.FindStringSubmatch(expr)
+		if match == nil {
+			return nil, fmt.Errorf("invalid path expression %q near %q", path, expr)
+		}
+
+		switch {
+		case match[1] != "":
+			segments = append(segments, match[1])
+		case match[2] != "":
+			segments = append(segments, match[2])
+		default:
+			segments = append(segments, match[3])
+		}
+		expr = expr[len(match[0]):]
+	}
+	return segments, nil
+}
+
+// lookupPath walks the decoded JSON value following the segments. Missing
+// keys, out of range indexes and scalar values result in nil.
+func lookupPath(data interface{}, segments []string) interface{} {
+	current := data
+	for _, segment := range segments {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			current = value[segment]
+
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			current = value[index]
+
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// ExtractPath returns the value found at the JSON pointer or JSONPath
+// expression informed, or nil when the path does not exist in data.
+func ExtractPath(data interface{}, path string) (interface{}, error) {
+	segments, err := compilePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return lookupPath(data, segments), nil
+}
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,52 +10,342 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+func init() {
+	Register("redis", newRedisFromSettings)
+}
+
+// Redis topologies supported by the adapter
+const (
+	RedisStandalone = "standalone"
+	RedisCluster    = "cluster"
+	RedisSentinel   = "sentinel"
+)
+
 type RedisAdapter interface {
 	Adapter
 }
 
+// RedisOptions contains the connection and data retrieval settings of the Redis adapter
+type RedisOptions struct {
+	// Addrs is the address of the server, or the seed list of cluster/sentinel nodes
+	Addrs []string
+
+	// Username and Password are the ACL credentials of the connection
+	Username string
+	Password string
+
+	// DB is the database index selected by standalone and sentinel connections
+	DB int
+
+	// TLS enables in-transit encryption, required by encrypted ElastiCache clusters
+	TLS bool
+
+	// InsecureSkipVerify disables the validation of the server certificate
+	InsecureSkipVerify bool
+
+	// Topology indicates whether the connection is standalone, cluster or sentinel
+	Topology string
+
+	// MasterName is the name of the master monitored by the sentinels
+	MasterName string
+
+	// SentinelUsername and SentinelPassword are the credentials of the sentinel
+	// nodes, which are usually different from the ones of the data nodes
+	SentinelUsername string
+	SentinelPassword string
+
+	// Command is the data command executed by the adapter (GET, HGETALL, HMGET,
+	// LRANGE, ZRANGE or SMEMBERS). GET is used when empty.
+	Command string
+
+	// Fields lists the hash fields returned by HMGET
+	Fields []string
+
+	// Start and Stop are the range used by LRANGE and ZRANGE
+	Start int64
+	Stop  int64
+
+	// WithScores returns the members of ZRANGE along with their scores
+	WithScores bool
+}
+
 type redisAdapter struct {
-	client     *redis.Client
+	client     redis.UniversalClient
 	keyPattern string
 	attr       map[string]interface{}
+	options    RedisOptions
+}
+
+func NewRedisAdapter(endpoint, pass, keyPattern string, attributes map[string]interface{}) (RedisAdapter, error) {
+	return NewRedisAdapterWithOptions(RedisOptions{
+		Addrs:    []string{endpoint},
+		Password: pass,
+		Stop:     -1,
+	}, keyPattern, attributes)
 }
 
-func NewRedisAdapter(endpoint, pass, keyPattern string, attributes map[string]interface{}) RedisAdapter {
+// NewRedisAdapterWithOptions creates a Redis adapter using the topology and
+// data command informed in the options
+func NewRedisAdapterWithOptions(options RedisOptions, keyPattern string, attributes map[string]interface{}) (RedisAdapter, error) {
+	options.Command = strings.ToUpper(options.Command)
+	switch options.Command {
+	case "":
+		options.Command = "GET"
+	case "GET", "HGETALL", "HMGET", "LRANGE", "ZRANGE", "SMEMBERS":
+	default:
+		return nil, fmt.Errorf("unsupported redis command: %s", options.Command)
+	}
+
+	if options.Command == "HMGET" && len(options.Fields) == 0 {
+		return nil, fmt.Errorf("the HMGET command requires the hash fields")
+	}
+
+	if _, err := ParseTemplate(keyPattern); err != nil {
+		return nil, fmt.Errorf("invalid redis key pattern: %v", err)
+	}
+
+	client, err := newRedisClient(options)
+	if err != nil {
+		return nil, err
+	}
+
 	return &redisAdapter{
-		client: redis.NewClient(
-			&redis.Options{
-				Addr:     endpoint,
-				Password: pass,
-				DB:       0,
-			},
-		),
+		client:     client,
 		attr:       attributes,
 		keyPattern: keyPattern,
+		options:    options,
+	}, nil
+}
+
+// newRedisClient creates the client of the topology informed in the options
+func newRedisClient(options RedisOptions) (redis.UniversalClient, error) {
+	universal := &redis.UniversalOptions{
+		Addrs:            options.Addrs,
+		Username:         options.Username,
+		Password:         options.Password,
+		SentinelUsername: options.SentinelUsername,
+		SentinelPassword: options.SentinelPassword,
+		DB:               options.DB,
+		MasterName:       options.MasterName,
+	}
+	if options.TLS {
+		universal.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: options.InsecureSkipVerify,
+		}
+	}
+
+	var client redis.UniversalClient
+	switch options.Topology {
+	case "", RedisStandalone:
+		client = redis.NewClient(universal.Simple())
+	case RedisCluster:
+		client = redis.NewClusterClient(universal.Cluster())
+	case RedisSentinel:
+		if options.MasterName == "" {
+			return nil, fmt.Errorf("the sentinel topology requires the master name")
+		}
+		client = redis.NewFailoverClient(universal.Failover())
+	default:
+		return nil, fmt.Errorf("unsupported redis topology: %s", options.Topology)
+	}
+
+	return client, nil
+}
+
+// newRedisFromSettings creates a Redis adapter from the connector settings
+func newRedisFromSettings(settings Settings) (Adapter, error) {
+	var (
+		options = RedisOptions{}
+		err     error
+		db      int64
+	)
+
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
 	}
+
+	if options.Addrs, err = settings.Strings("addrs"); err != nil {
+		return nil, err
+	}
+	endpoint, err := settings.String("endpoint")
+	if err != nil {
+		return nil, err
+	}
+	if endpoint != "" {
+		options.Addrs = append([]string{endpoint}, options.Addrs...)
+	}
+
+	if options.Fields, err = settings.Strings("fields"); err != nil {
+		return nil, err
+	}
+	if db, err = settings.Int("db", 0); err != nil {
+		return nil, err
+	}
+	options.DB = int(db)
+	if options.Start, err = settings.Int("start", 0); err != nil {
+		return nil, err
+	}
+	if options.Stop, err = settings.Int("stop", -1); err != nil {
+		return nil, err
+	}
+
+	for key, target := range map[string]*string{
+		"username":   &options.Username,
+		"password":   &options.Password,
+		"mode":       &options.Topology,
+		"masterName": &options.MasterName,
+
+		"sentinelUsername": &options.SentinelUsername,
+		"sentinelPassword": &options.SentinelPassword,
+		"command":          &options.Command,
+	} {
+		if *target, err = settings.String(key); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, target := range map[string]*bool{
+		"tls":                &options.TLS,
+		"insecureSkipVerify": &options.InsecureSkipVerify,
+		"withScores":         &options.WithScores,
+	} {
+		if *target, err = settings.Bool(key); err != nil {
+			return nil, err
+		}
+	}
+
+	adapter, err := NewRedisAdapterWithOptions(options, settings.KeyPattern, attributes)
+	return adapter, settings.wrap(err)
 }
 
-func (r *redisAdapter) GetData(args []AdapterAttribute) (interface{}, error) {
-	if len(args) == 0 {
-		return nil, fmt.Errorf("the data key value was not informed")
+func (r *redisAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	searchKey, err := renderText(r.keyPattern, args)
+	if err != nil {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("the data key value was not informed")
+		}
+		return nil, fmt.Errorf("failed to build the redis key: %v", err)
 	}
 
-	searchKey := strings.ReplaceAll(
-		r.keyPattern,
-		fmt.Sprintf("{%s}", args[0].Name),
-		fmt.Sprintf("%v", args[0].Value))
+	switch r.options.Command {
+	case "HGETALL":
+		values, err := r.client.HGetAll(ctx, searchKey).Result()
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(values))
+		for field, value := range values {
+			result[field] = decodeRedisValue(value)
+		}
+		return result, nil
+
+	case "HMGET":
+		fields := make([]string, len(r.options.Fields))
+		for i, field := range r.options.Fields {
+			if fields[i], err = renderText(field, args); err != nil {
+				return nil, fmt.Errorf("failed to build the hash field: %v", err)
+			}
+		}
+		values, err := r.client.HMGet(ctx, searchKey, fields...).Result()
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(fields))
+		for i, field := range fields {
+			if value, ok := values[i].(string); ok {
+				result[field] = decodeRedisValue(value)
+			} else {
+				result[field] = nil
+			}
+		}
+		return result, nil
+
+	case "LRANGE":
+		values, err := r.client.LRange(ctx, searchKey, r.options.Start, r.options.Stop).Result()
+		if err != nil {
+			return nil, err
+		}
+		return decodeRedisValues(values), nil
+
+	case "ZRANGE":
+		if !r.options.WithScores {
+			values, err := r.client.ZRange(ctx, searchKey, r.options.Start, r.options.Stop).Result()
+			if err != nil {
+				return nil, err
+			}
+			return decodeRedisValues(values), nil
+		}
 
-	data, err := r.client.Get(context.Background(), searchKey).Result()
+		members, err := r.client.ZRangeWithScores(ctx, searchKey, r.options.Start, r.options.Stop).Result()
+		if err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, len(members))
+		for i, member := range members {
+			result[i] = map[string]interface{}{
+				"member": decodeRedisValue(fmt.Sprintf("%v", member.Member)),
+				"score":  member.Score,
+			}
+		}
+		return result, nil
+
+	case "SMEMBERS":
+		values, err := r.client.SMembers(ctx, searchKey).Result()
+		if err != nil {
+			return nil, err
+		}
+		return decodeRedisValues(values), nil
+	}
+
+	// a missing key is a null value, so that it can be cached like any other result
+	data, err := r.client.Get(ctx, searchKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var result map[string]interface{}
+	var result interface{}
 	if err := json.Unmarshal([]byte(data), &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// HealthCheck sends a PING to the Redis server or cluster
+func (r *redisAdapter) HealthCheck(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections of the Redis client
+func (r *redisAdapter) Close() error {
+	return r.client.Close()
+}
+
 func (r *redisAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
 	return getParameters(r.attr, args)
 }
+
+// decodeRedisValue returns the JSON content stored in the value, or the value
+// itself when it is plain text
+func decodeRedisValue(value string) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+func decodeRedisValues(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = decodeRedisValue(value)
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"testing"
@@ -11,9 +12,12 @@ import (
 
 // Testes para RedisAdapter
 func TestNewRedisAdapter(t *testing.T) {
-	adapter := NewRedisAdapter("localhost:6379", "password", "user:{userId}", map[string]interface{}{
+	adapter, err := NewRedisAdapter("localhost:6379", "password", "user:{userId}", map[string]interface{}{
 		"userId": "string",
 	})
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
 
 	if adapter == nil {
 		t.Fatal("NewRedisAdapter retornou nil")
@@ -39,7 +43,10 @@ func TestRedisAdapter_GetParameters(t *testing.T) {
 		"type":   "string",
 	}
 
-	adapter := NewRedisAdapter("localhost:6379", "", "user:{userId}", attributes)
+	adapter, err := NewRedisAdapter("localhost:6379", "", "user:{userId}", attributes)
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
 
 	args := map[string]interface{}{
 		"userId": "123",
@@ -113,7 +120,7 @@ func TestRedisAdapter_GetData_Success(t *testing.T) {
 	}
 
 	// Executar teste
-	result, err := adapter.GetData(args)
+	result, err := adapter.GetData(context.Background(), args)
 	if err != nil {
 		t.Fatalf("GetData() erro = %v", err)
 	}
@@ -134,9 +141,12 @@ func TestRedisAdapter_GetData_Success(t *testing.T) {
 }
 
 func TestRedisAdapter_GetData_NoArgs(t *testing.T) {
-	adapter := NewRedisAdapter("localhost:6379", "", "user:{userId}", map[string]interface{}{})
+	adapter, err := NewRedisAdapter("localhost:6379", "", "user:{userId}", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
 
-	_, err := adapter.GetData([]AdapterAttribute{})
+	_, err = adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err == nil {
 		t.Fatal("esperado erro quando não há argumentos")
 	}
@@ -160,7 +170,7 @@ func TestRedisAdapter_GetData_RedisError(t *testing.T) {
 		{Name: "userId", Type: "string", Value: "123"},
 	}
 
-	_, err := adapter.GetData(args)
+	_, err := adapter.GetData(context.Background(), args)
 	if err == nil {
 		t.Fatal("esperado erro de conexão Redis")
 	}
@@ -187,8 +197,222 @@ func TestRedisAdapter_GetData_InvalidJSON(t *testing.T) {
 		{Name: "userId", Type: "string", Value: "123"},
 	}
 
-	_, err = adapter.GetData(args)
+	_, err = adapter.GetData(context.Background(), args)
 	if err == nil {
 		t.Fatal("esperado erro de JSON inválido")
 	}
 }
+
+func TestRedisAdapter_GetData_Commands(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("erro ao iniciar miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	mr.HSet("CVN_1", "nome", "Convenio 1", "limite", `{"valor": 100}`)
+	mr.RPush("LST_1", "a", "b", "c")
+	mr.ZAdd("RNK_1", 2, "segundo")
+	mr.ZAdd("RNK_1", 1, "primeiro")
+	mr.SAdd("SET_1", "x")
+
+	tests := []struct {
+		name     string
+		key      string
+		options  RedisOptions
+		expected interface{}
+	}{
+		{
+			name:    "HGETALL",
+			key:     "CVN_{codigo}",
+			options: RedisOptions{Command: "hgetall"},
+			expected: map[string]interface{}{
+				"nome":   "Convenio 1",
+				"limite": map[string]interface{}{"valor": float64(100)},
+			},
+		},
+		{
+			name:     "HMGET",
+			key:      "CVN_{codigo}",
+			options:  RedisOptions{Command: "HMGET", Fields: []string{"nome", "inexistente"}},
+			expected: map[string]interface{}{"nome": "Convenio 1", "inexistente": nil},
+		},
+		{
+			name:     "LRANGE",
+			key:      "LST_{codigo}",
+			options:  RedisOptions{Command: "LRANGE", Start: 1, Stop: -1},
+			expected: []interface{}{"b", "c"},
+		},
+		{
+			name:     "ZRANGE",
+			key:      "RNK_{codigo}",
+			options:  RedisOptions{Command: "ZRANGE", Stop: -1},
+			expected: []interface{}{"primeiro", "segundo"},
+		},
+		{
+			name:    "ZRANGE com scores",
+			key:     "RNK_{codigo}",
+			options: RedisOptions{Command: "ZRANGE", Stop: 0, WithScores: true},
+			expected: []interface{}{
+				map[string]interface{}{"member": "primeiro", "score": float64(1)},
+			},
+		},
+		{
+			name:     "SMEMBERS",
+			key:      "SET_{codigo}",
+			options:  RedisOptions{Command: "SMEMBERS"},
+			expected: []interface{}{"x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Addrs = []string{mr.Addr()}
+			adapter, err := NewRedisAdapterWithOptions(tt.options, tt.key, nil)
+			if err != nil {
+				t.Fatalf("NewRedisAdapterWithOptions() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), []AdapterAttribute{
+				{Name: "codigo", Type: "Int", Value: 1},
+			})
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("result = %#v, esperado %#v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedisAdapter_GetData_SelectDB(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("erro ao iniciar miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	mr.DB(3).Set("user:123", `{"id": "123"}`)
+
+	adapter, err := NewRedisAdapterWithOptions(RedisOptions{Addrs: []string{mr.Addr()}, DB: 3}, "user:{userId}", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapterWithOptions() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "userId", Type: "string", Value: "123"},
+	})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if result.(map[string]interface{})["id"] != "123" {
+		t.Errorf("result = %v, esperado id 123", result)
+	}
+}
+
+func TestNewRedisAdapterWithOptions_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RedisOptions
+	}{
+		{"comando não suportado", RedisOptions{Command: "DEL"}},
+		{"HMGET sem campos", RedisOptions{Command: "HMGET"}},
+		{"topologia desconhecida", RedisOptions{Topology: "ring"}},
+		{"sentinel sem master", RedisOptions{Topology: RedisSentinel}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRedisAdapterWithOptions(tt.options, "key", nil); err == nil {
+				t.Error("esperado erro de configuração")
+			}
+		})
+	}
+}
+
+func TestRedisAdapter_GetData_MultiplePlaceholders(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("erro ao iniciar miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	mr.Set("CVN_000042_CONSIGNADO", `{"id": 42}`)
+
+	adapter, err := NewRedisAdapterWithOptions(RedisOptions{Addrs: []string{mr.Addr()}},
+		"CVN_{codigoConvenio|pad:6}_{produto|upper}", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapterWithOptions() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 42},
+		{Name: "produto", Type: "String", Value: "consignado"},
+	})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if result.(map[string]interface{})["id"] != float64(42) {
+		t.Errorf("result = %v, esperado id 42", result)
+	}
+
+	// o segundo placeholder sem valor deve ser reportado como erro
+	_, err = adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 42},
+	})
+	if err == nil {
+		t.Fatal("esperado erro para placeholder sem valor")
+	}
+}
+
+func TestRedisAdapter_GetData_ConstantKey(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("erro ao iniciar miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	mr.Set("convenios:ativos", `[1, 2]`)
+
+	// uma chave sem placeholders não depende dos argumentos
+	adapter, err := NewRedisAdapter(mr.Addr(), "", "convenios:ativos", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+	result, err := adapter.GetData(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+	if !reflect.DeepEqual(result, []interface{}{float64(1), float64(2)}) {
+		t.Errorf("result = %v, esperado [1 2]", result)
+	}
+}
+
+func TestRedisAdapter_GetData_MissingKey(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("erro ao iniciar miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	// a chave inexistente é um valor nulo, não uma falha do Redis
+	adapter, err := NewRedisAdapter(mr.Addr(), "", "convenio:{codigo}", nil)
+	if err != nil {
+		t.Fatalf("NewRedisAdapter() erro = %v", err)
+	}
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 7}})
+	if err != nil || result != nil {
+		t.Errorf("GetData() = %v, erro = %v, esperado nil para chave inexistente", result, err)
+	}
+}
+
+func TestNewRedisAdapter_InvalidKeyPattern(t *testing.T) {
+	adapter, err := NewRedisAdapter("localhost:6379", "", "user:{userId|reverse}", nil)
+	if err == nil || adapter != nil {
+		t.Errorf("NewRedisAdapter() = %v, erro = %v, esperado erro de key pattern", adapter, err)
+	}
+}
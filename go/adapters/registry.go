@@ -0,0 +1,236 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+)
+
+// ErrUnsupportedAdapter is returned when no factory was registered for the adapter name
+var ErrUnsupportedAdapter = errors.New("unsupported adapter")
+
+// Factory creates an adapter from the settings of a connector. It must
+// validate the settings and return a *ConfigError when they are invalid.
+type Factory func(settings Settings) (Adapter, error)
+
+// Settings contains the values received by a Factory
+type Settings struct {
+	// Adapter is the name the factory was registered with
+	Adapter string
+
+	// Config is the configuration of the GraphQL API
+	Config *types.Config
+
+	// KeyPattern is the key template informed in the connector
+	KeyPattern string
+
+	// Raw is the adapterConfig object of the connector, as decoded from JSON
+	Raw map[string]interface{}
+}
+
+// ConfigError reports an invalid setting of an adapter
+type ConfigError struct {
+	Adapter string
+	Key     string
+	Reason  string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("invalid %s adapter config: %s", e.Adapter, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s adapter config %q: %s", e.Adapter, e.Key, e.Reason)
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+// Register makes an adapter available to the connectors under the name
+// informed. It panics if the factory is nil or the name was already
+// registered, the same way database/sql handles drivers.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("adapters: Register factory is nil")
+	}
+	if _, exists := factories[name]; exists {
+		panic("adapters: Register called twice for adapter " + name)
+	}
+	factories[name] = factory
+}
+
+// Adapters returns the sorted names of the registered adapters
+func Adapters() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates an adapter using the factory registered under the name
+func New(name string, settings Settings) (Adapter, error) {
+	factoriesMu.RLock()
+	factory, exists := factories[name]
+	factoriesMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAdapter, name)
+	}
+
+	settings.Adapter = name
+	if settings.Raw == nil {
+		settings.Raw = make(map[string]interface{})
+	}
+	return factory(settings)
+}
+
+// invalid creates a *ConfigError for the setting key
+func (s Settings) invalid(key, reason string, args ...interface{}) error {
+	return &ConfigError{Adapter: s.Adapter, Key: key, Reason: fmt.Sprintf(reason, args...)}
+}
+
+// wrap converts an error returned while building the adapter into a *ConfigError
+func (s Settings) wrap(err error) error {
+	var configErr *ConfigError
+	if err == nil || errors.As(err, &configErr) {
+		return err
+	}
+	return &ConfigError{Adapter: s.Adapter, Reason: err.Error()}
+}
+
+// String returns an optional text setting
+func (s Settings) String(key string) (string, error) {
+	value, exists := s.Raw[key]
+	if !exists || value == nil {
+		return "", nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", s.invalid(key, "expected a string, got %T", value)
+	}
+	return text, nil
+}
+
+// RequiredString returns a text setting that must be informed
+func (s Settings) RequiredString(key string) (string, error) {
+	text, err := s.String(key)
+	if err == nil && text == "" {
+		err = s.invalid(key, "is required")
+	}
+	return text, err
+}
+
+// Bool returns an optional boolean setting
+func (s Settings) Bool(key string) (bool, error) {
+	value, exists := s.Raw[key]
+	if !exists || value == nil {
+		return false, nil
+	}
+	flag, ok := value.(bool)
+	if !ok {
+		return false, s.invalid(key, "expected a boolean, got %T", value)
+	}
+	return flag, nil
+}
+
+// Int returns an optional integer setting, or the default value when it is absent
+func (s Settings) Int(key string, defaultValue int64) (int64, error) {
+	value, exists := s.Raw[key]
+	if !exists || value == nil {
+		return defaultValue, nil
+	}
+	number, ok := value.(float64)
+	if !ok || number != float64(int64(number)) {
+		return 0, s.invalid(key, "expected an integer, got %v", value)
+	}
+	return int64(number), nil
+}
+
+// Strings returns an optional list of texts setting
+func (s Settings) Strings(key string) ([]string, error) {
+	values := make([]string, 0)
+	value, exists := s.Raw[key]
+	if !exists || value == nil {
+		return values, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, s.invalid(key, "expected a list, got %T", value)
+	}
+	for _, item := range items {
+		text, ok := item.(string)
+		if !ok {
+			return nil, s.invalid(key, "expected a list of strings, got %T", item)
+		}
+		values = append(values, text)
+	}
+	return values, nil
+}
+
+// Map returns an optional object setting
+func (s Settings) Map(key string) (map[string]interface{}, error) {
+	value, exists := s.Raw[key]
+	if !exists || value == nil {
+		return make(map[string]interface{}), nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, s.invalid(key, "expected an object, got %T", value)
+	}
+	return object, nil
+}
+
+// StringMap returns an optional object setting whose values are texts
+func (s Settings) StringMap(key string) (map[string]interface{}, error) {
+	object, err := s.Map(key)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range object {
+		if _, ok := value.(string); !ok {
+			return nil, s.invalid(key, "expected a string for %s, got %T", name, value)
+		}
+	}
+	return object, nil
+}
+
+// Attributes returns the GraphQL arguments declared in the "attr" setting,
+// validating the declared types
+func (s Settings) Attributes() (map[string]interface{}, error) {
+	attributes, err := s.StringMap("attr")
+	if err != nil {
+		return nil, err
+	}
+	for name, declaration := range attributes {
+		if _, err := parseArgumentType(declaration.(string)); err != nil {
+			return nil, s.invalid("attr", "%s: %v", name, err)
+		}
+	}
+	return attributes, nil
+}
+
+// Decode copies the settings to a structure, using its json tags
+func (s Settings) Decode(target interface{}) error {
+	content, err := json.Marshal(s.Raw)
+	if err != nil {
+		return s.invalid("", "%v", err)
+	}
+	if err := json.Unmarshal(content, target); err != nil {
+		return s.invalid("", "%v", err)
+	}
+	return nil
+}
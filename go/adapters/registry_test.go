@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"errors"
+	"testing"
+)
+
+// unregister remove um adapter registrado pelo teste, permitindo executá-lo novamente
+func unregister(name string) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	delete(factories, name)
+}
+
+func TestRegistry_CustomAdapter(t *testing.T) {
+	var received Settings
+	Register("registry-test", func(settings Settings) (Adapter, error) {
+		received = settings
+		return &mockAdapter{}, nil
+	})
+	t.Cleanup(func() { unregister("registry-test") })
+
+	found := false
+	for _, name := range Adapters() {
+		if name == "registry-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Adapters() não contém o adapter registrado")
+	}
+
+	if _, err := New("registry-test", Settings{KeyPattern: "{id}"}); err != nil {
+		t.Fatalf("New() erro = %v", err)
+	}
+
+	if received.Adapter != "registry-test" || received.KeyPattern != "{id}" || received.Raw == nil {
+		t.Errorf("Settings recebidas = %+v", received)
+	}
+}
+
+func TestRegistry_Errors(t *testing.T) {
+	if _, err := New("inexistente", Settings{}); !errors.Is(err, ErrUnsupportedAdapter) {
+		t.Errorf("New() erro = %v, esperado ErrUnsupportedAdapter", err)
+	}
+
+	tests := []struct {
+		name    string
+		adapter string
+		raw     map[string]interface{}
+		key     string
+	}{
+		{"attr não é objeto", "redis", map[string]interface{}{"attr": "id"}, "attr"},
+		{"tipo de attr inválido", "sql", map[string]interface{}{"attr": map[string]interface{}{"id": 1.0}}, "attr"},
+		{"headers inválidos", "rest", map[string]interface{}{"baseUrl": "http://localhost", "headers": []interface{}{}}, "headers"},
+		{"baseUrl ausente", "rest", map[string]interface{}{}, "baseUrl"},
+		{"auth não booleano", "rest", map[string]interface{}{"baseUrl": "http://localhost", "auth": "true"}, "auth"},
+		{"status inválido", "rest", map[string]interface{}{"baseUrl": "http://localhost", "statusErrors": map[string]interface{}{"abc": "erro"}}, "statusErrors"},
+		{"db não inteiro", "redis", map[string]interface{}{"endpoint": "localhost:6379", "db": 1.5}, "db"},
+		{"bucket ausente", "s3", map[string]interface{}{}, "bucket"},
+		{"tabela ausente", "dynamodb", map[string]interface{}{}, "table"},
+		{"query SQL ausente", "sql", map[string]interface{}{"driver": "sqlite", "dsn": ":memory:"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.adapter, Settings{Raw: tt.raw})
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("New() erro = %v, esperado *ConfigError", err)
+			}
+			if configErr.Adapter != tt.adapter || configErr.Key != tt.key {
+				t.Errorf("ConfigError = %+v, esperado adapter %s e chave %q", configErr, tt.adapter, tt.key)
+			}
+		})
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() esperado panic para adapter duplicado")
+		}
+	}()
+	Register("rest", newRestFromSettings)
+}
@@ -0,0 +1,375 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CircuitState represents the state of the circuit breaker of an adapter
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// ErrCircuitOpen is returned while the circuit breaker rejects calls to a
+// failing dependency.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// defaultJitter is the fraction of the delay randomized when the policy does not
+// inform its own, so that the replicas of the API do not retry in lockstep
+const defaultJitter = 0.2
+
+// defaultRetryableStatus contains the status codes retried when the policy
+// does not inform its own list
+var defaultRetryableStatus = []int{429, 502, 503, 504}
+
+// ResiliencePolicy contains the retry and circuit breaker settings applied to
+// the calls of an adapter
+type ResiliencePolicy struct {
+	// MaxAttempts is the total number of calls made before giving up (minimum 1)
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits the delay between two attempts
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the delay after every retry
+	Multiplier float64
+
+	// Jitter is the fraction (0 to 1) of the delay that is randomized. It is
+	// 0.2 when zero; a negative value disables it.
+	Jitter float64
+
+	// RetryableStatus lists the upstream status codes that can be retried
+	RetryableStatus []int
+
+	// Breaker enables the circuit breaker when informed
+	Breaker *BreakerPolicy
+
+	// OnStateChange is called every time the circuit breaker changes its state
+	OnStateChange func(from, to CircuitState)
+}
+
+// BreakerPolicy contains the circuit breaker settings
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+
+	// OpenTimeout is how long the circuit stays open before probing the dependency
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxCalls is the number of concurrent probes allowed while half-open
+	HalfOpenMaxCalls int
+}
+
+type resilientAdapter struct {
+	Adapter
+	policy  ResiliencePolicy
+	breaker *circuitBreaker
+}
+
+// NewResilientAdapter wraps the adapter with retries using exponential backoff
+// with jitter and, optionally, a circuit breaker.
+func NewResilientAdapter(adapter Adapter, policy ResiliencePolicy) Adapter {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = 100 * time.Millisecond
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 5 * time.Second
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 2
+	}
+	if policy.Jitter == 0 {
+		policy.Jitter = defaultJitter
+	}
+	policy.Jitter = math.Max(0, math.Min(policy.Jitter, 1))
+	if policy.RetryableStatus == nil {
+		policy.RetryableStatus = defaultRetryableStatus
+	}
+
+	resilient := &resilientAdapter{
+		Adapter: adapter,
+		policy:  policy,
+	}
+	if policy.Breaker != nil {
+		resilient.breaker = newCircuitBreaker(*policy.Breaker, policy.OnStateChange)
+	}
+	return resilient
+}
+
+func (r *resilientAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	var (
+		err   error
+		delay = r.policy.InitialBackoff
+	)
+
+	for attempt := 1; attempt <= r.policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := sleep(ctx, r.jitter(delay)); err != nil {
+				return nil, err
+			}
+			delay = time.Duration(math.Min(float64(delay)*r.policy.Multiplier, float64(r.policy.MaxBackoff)))
+		}
+
+		var data interface{}
+		data, err = r.call(ctx, args)
+		if err == nil {
+			return data, nil
+		}
+		if !r.retryable(ctx, err) {
+			return nil, err
+		}
+	}
+
+	if r.policy.MaxAttempts > 1 {
+		return nil, fmt.Errorf("giving up after %d attempts: %w", r.policy.MaxAttempts, err)
+	}
+	return nil, err
+}
+
+// call performs a single attempt, respecting the circuit breaker state
+func (r *resilientAdapter) call(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	if r.breaker == nil {
+		return r.Adapter.GetData(ctx, args)
+	}
+
+	if !r.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	data, err := r.Adapter.GetData(ctx, args)
+
+	// the cancellation or deadline of the caller says nothing about the dependency
+	if err != nil && ctx.Err() != nil {
+		r.breaker.release()
+		return data, err
+	}
+	r.breaker.record(err == nil || !r.failure(err))
+	return data, err
+}
+
+// retryable indicates whether a new attempt can be made after the error
+func (r *resilientAdapter) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	return r.transient(err)
+}
+
+// transient classifies the errors caused by an unhealthy dependency: network
+// failures, per-attempt timeouts, the retryable status codes and the gRPC
+// codes of an unavailable or overloaded server.
+func (r *resilientAdapter) transient(err error) bool {
+	var coded interface{ Status() int }
+	if errors.As(err, &coded) {
+		return slices.Contains(r.policy.RetryableStatus, coded.Status())
+	}
+
+	if code, ok := grpcCode(err); ok {
+		switch code {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// failure classifies the errors counted by the circuit breaker: server errors,
+// the retryable status codes, network failures and upstream timeouts. Errors of
+// the caller (e.g. invalid arguments) and answers of the dependency (e.g. a 404,
+// a gRPC NotFound, a missing parameter or the errors of a remote GraphQL
+// service) mean the dependency is up.
+func (r *resilientAdapter) failure(err error) bool {
+	var coded interface{ Status() int }
+	if errors.As(err, &coded) {
+		return coded.Status() >= 500 || slices.Contains(r.policy.RetryableStatus, coded.Status())
+	}
+
+	if code, ok := grpcCode(err); ok {
+		switch code {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted,
+			codes.Internal, codes.Unknown, codes.DataLoss:
+			return true
+		}
+		return false
+	}
+
+	var (
+		argErr     *ArgumentError
+		graphqlErr *GraphQLError
+	)
+	return !errors.As(err, &argErr) && !errors.As(err, &graphqlErr) && !isParameterNotFound(err)
+}
+
+// grpcCode returns the code of a gRPC status error
+func grpcCode(err error) (codes.Code, bool) {
+	var coded interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &coded) {
+		return codes.OK, false
+	}
+	return coded.GRPCStatus().Code(), true
+}
+
+func (r *resilientAdapter) jitter(delay time.Duration) time.Duration {
+	if r.policy.Jitter == 0 {
+		return delay
+	}
+	return time.Duration(float64(delay) * (1 + r.policy.Jitter*(2*rand.Float64()-1)))
+}
+
+// HealthCheck checks the wrapped adapter directly, so that the probe is
+// neither retried nor rejected by an open circuit breaker
+func (r *resilientAdapter) HealthCheck(ctx context.Context) error {
+	return CheckHealth(ctx, r.Adapter)
+}
+
+// Close releases the resources of the wrapped adapter
+func (r *resilientAdapter) Close() error {
+	return CloseAdapter(r.Adapter)
+}
+
+// sleep waits for the delay or until the context is done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	policy   BreakerPolicy
+	state    CircuitState
+	failures int
+	probes   int
+	openedAt time.Time
+	notify   func(from, to CircuitState)
+	now      func() time.Time
+}
+
+func newCircuitBreaker(policy BreakerPolicy, notify func(from, to CircuitState)) *circuitBreaker {
+	if policy.FailureThreshold < 1 {
+		policy.FailureThreshold = 5
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = 30 * time.Second
+	}
+	if policy.HalfOpenMaxCalls < 1 {
+		policy.HalfOpenMaxCalls = 1
+	}
+
+	return &circuitBreaker{
+		policy: policy,
+		state:  CircuitClosed,
+		notify: notify,
+		now:    time.Now,
+	}
+}
+
+// allow reports whether a call can be made, moving an expired open circuit
+// to half-open so that a limited number of probes reach the dependency.
+func (c *circuitBreaker) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case CircuitOpen:
+		if c.now().Sub(c.openedAt) < c.policy.OpenTimeout {
+			return false
+		}
+		c.transition(CircuitHalfOpen)
+		fallthrough
+
+	case CircuitHalfOpen:
+		if c.probes >= c.policy.HalfOpenMaxCalls {
+			return false
+		}
+		c.probes++
+	}
+	return true
+}
+
+// record registers the outcome of a call allowed by the breaker
+func (c *circuitBreaker) record(success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == CircuitHalfOpen {
+		c.probes--
+		if success {
+			c.transition(CircuitClosed)
+		} else {
+			c.transition(CircuitOpen)
+		}
+		return
+	}
+
+	if success {
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.state == CircuitClosed && c.failures >= c.policy.FailureThreshold {
+		c.transition(CircuitOpen)
+	}
+}
+
+// release gives back a call allowed by the breaker without recording its outcome
+func (c *circuitBreaker) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// transition changes the state of the breaker; it must be called holding the lock
+func (c *circuitBreaker) transition(to CircuitState) {
+	from := c.state
+	if from == to {
+		return
+	}
+
+	c.state = to
+	c.failures = 0
+	switch to {
+	case CircuitOpen:
+		c.openedAt = c.now()
+		c.probes = 0
+	case CircuitClosed:
+		c.probes = 0
+	}
+
+	if c.notify != nil {
+		c.notify(from, to)
+	}
+}
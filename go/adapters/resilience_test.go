@@ -0,0 +1,242 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Mock de adapter com respostas programáveis
+type mockAdapter struct {
+	calls int32
+	err   func(call int32) error
+}
+
+func (m *mockAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	call := atomic.AddInt32(&m.calls, 1)
+	if err := m.err(call); err != nil {
+		return nil, err
+	}
+	return "ok", nil
+}
+
+func (m *mockAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return nil, nil
+}
+
+func TestResilientAdapter_RetryStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": "recuperado"}`))
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	adapter := NewResilientAdapter(NewRestAdapter(cfg, server.URL, "flaky", false, nil, nil), ResiliencePolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Jitter:         0.5,
+	})
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if result != "recuperado" || calls != 3 {
+		t.Errorf("result = %v após %d chamadas, esperado recuperado após 3", result, calls)
+	}
+}
+
+func TestResilientAdapter_NonRetryableStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	adapter := NewResilientAdapter(NewRestAdapter(cfg, server.URL, "invalid", false, nil, nil), ResiliencePolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	})
+
+	if _, err := adapter.GetData(context.Background(), []AdapterAttribute{}); err == nil {
+		t.Fatal("esperado erro HTTP 400")
+	}
+
+	if calls != 1 {
+		t.Errorf("chamadas = %d, esperado 1 para status não retentável", calls)
+	}
+}
+
+func TestResilientAdapter_CircuitBreaker(t *testing.T) {
+	var (
+		healthy     atomic.Bool
+		transitions []CircuitState
+	)
+
+	mock := &mockAdapter{err: func(call int32) error {
+		if healthy.Load() {
+			return nil
+		}
+		return &statusError{code: http.StatusServiceUnavailable, url: "mock"}
+	}}
+
+	adapter := NewResilientAdapter(mock, ResiliencePolicy{
+		MaxAttempts: 1,
+		Breaker: &BreakerPolicy{
+			FailureThreshold: 2,
+			OpenTimeout:      50 * time.Millisecond,
+		},
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, to)
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		adapter.GetData(context.Background(), nil)
+	}
+
+	if _, err := adapter.GetData(context.Background(), nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("erro = %v, esperado ErrCircuitOpen", err)
+	}
+
+	if mock.calls != 2 {
+		t.Errorf("chamadas = %d, esperado 2 com o circuito aberto", mock.calls)
+	}
+
+	// após o timeout, uma chamada de teste fecha o circuito
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+
+	if _, err := adapter.GetData(context.Background(), nil); err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	expected := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("transições = %v, esperado %v", transitions, expected)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("transições = %v, esperado %v", transitions, expected)
+		}
+	}
+}
+
+func TestResilientAdapter_ContextCanceled(t *testing.T) {
+	mock := &mockAdapter{err: func(call int32) error {
+		return &statusError{code: http.StatusServiceUnavailable, url: "mock"}
+	}}
+
+	adapter := NewResilientAdapter(mock, ResiliencePolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := adapter.GetData(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("erro = %v, esperado context.DeadlineExceeded", err)
+	}
+
+	if mock.calls != 1 {
+		t.Errorf("chamadas = %d, esperado 1 antes do cancelamento", mock.calls)
+	}
+}
+
+func TestResilientAdapter_CircuitBreakerFailures(t *testing.T) {
+	var failure error = &statusError{code: http.StatusInternalServerError, url: "mock"}
+	mock := &mockAdapter{err: func(call int32) error { return failure }}
+
+	adapter := NewResilientAdapter(mock, ResiliencePolicy{
+		MaxAttempts: 1,
+		Breaker:     &BreakerPolicy{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond},
+	})
+
+	// um status 500 não é retentado, mas conta como falha do circuito
+	for i := 0; i < 2; i++ {
+		adapter.GetData(context.Background(), nil)
+	}
+	if _, err := adapter.GetData(context.Background(), nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("erro = %v, esperado ErrCircuitOpen após dois status 500", err)
+	}
+
+	// a chamada de teste cancelada pelo cliente não fecha o circuito
+	time.Sleep(60 * time.Millisecond)
+	failure = context.Canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	adapter.GetData(ctx, nil)
+
+	failure = &statusError{code: http.StatusBadGateway, url: "mock"}
+	if _, err := adapter.GetData(context.Background(), nil); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("erro = %v, esperado nova chamada de teste", err)
+	}
+	if _, err := adapter.GetData(context.Background(), nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("erro = %v, esperado ErrCircuitOpen após falha da chamada de teste", err)
+	}
+
+	// erros do cliente não contam como falha
+	var argErr error = &ArgumentError{Name: "codigo", Type: "Int!", Reason: "a value is required"}
+	closed := NewResilientAdapter(&mockAdapter{err: func(call int32) error { return argErr }}, ResiliencePolicy{
+		Breaker: &BreakerPolicy{FailureThreshold: 1},
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := closed.GetData(context.Background(), nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("erro = %v, esperado circuito fechado para erros de argumento", err)
+		}
+	}
+}
+
+func TestResilientAdapter_GRPCCodes(t *testing.T) {
+	tests := []struct {
+		code        codes.Code
+		calls       int32
+		breakerOpen bool
+	}{
+		{codes.Unavailable, 3, true},
+		{codes.ResourceExhausted, 3, true},
+		{codes.Internal, 1, true},
+		{codes.NotFound, 1, false},
+		{codes.InvalidArgument, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			failure := fmt.Errorf("failed to invoke gRPC method: %w", status.Error(tt.code, "falha"))
+			mock := &mockAdapter{err: func(call int32) error { return failure }}
+			retrying := NewResilientAdapter(mock, ResiliencePolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+			retrying.GetData(context.Background(), nil)
+			if mock.calls != tt.calls {
+				t.Errorf("chamadas = %d, esperado %d", mock.calls, tt.calls)
+			}
+
+			breaking := NewResilientAdapter(mock, ResiliencePolicy{
+				MaxAttempts: 1,
+				Breaker:     &BreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Hour},
+			})
+			breaking.GetData(context.Background(), nil)
+			_, err := breaking.GetData(context.Background(), nil)
+			if open := errors.Is(err, ErrCircuitOpen); open != tt.breakerOpen {
+				t.Errorf("circuito aberto = %v, esperado %v", open, tt.breakerOpen)
+			}
+		})
+	}
+}
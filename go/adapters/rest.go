@@ -1,18 +1,100 @@
 package adapters
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/raywall/cloud-service-pack/go/graphql/types"
 )
 
+func init() {
+	Register("rest", newRestFromSettings)
+}
+
+// Authentication methods supported by the REST adapter
+const (
+	RestAuthBearer = "bearer"
+	RestAuthSigV4  = "sigv4"
+)
+
 type RestAdapter interface {
 	Adapter
+
+	// WithRequest defines the HTTP method used by the adapter and an optional
+	// body template, whose {attr} placeholders are filled with the arguments.
+	WithRequest(method string, body interface{}) (RestAdapter, error)
+
+	// WithResponse defines how the value is extracted from the upstream
+	// response and how non-success status codes are handled.
+	WithResponse(response RestResponse) (RestAdapter, error)
+
+	// WithPagination defines how an upstream that pages its results is read
+	WithPagination(pagination RestPagination) (RestAdapter, error)
+
+	// WithHTTPClient defines the timeout, connection pool, TLS and proxy
+	// settings of the requests
+	WithHTTPClient(options HTTPClientOptions) (RestAdapter, error)
+
+	// WithSigV4 signs the requests with AWS Signature Version 4 instead of
+	// sending the bearer token
+	WithSigV4(options SigV4Options) (RestAdapter, error)
+
+	// WithAuthProfile authenticates the requests with a named auth profile
+	// instead of the access token of the GraphQL API
+	WithAuthProfile(profile types.AuthProfile) (RestAdapter, error)
+}
+
+// RestResponse contains the settings used to read the upstream response
+type RestResponse struct {
+	// Path is a JSON pointer (e.g. /data/items) or JSONPath (e.g. $.data.items[0])
+	// selecting the value returned by the adapter. Use "$" to return the whole
+	// document. When empty, the "data" envelope of JSON objects is returned.
+	Path string
+
+	// NotFoundAsNull indicates that a 404 status results in a null value
+	// instead of an error
+	NotFoundAsNull bool
+
+	// StatusErrors maps upstream status codes to the message of the GraphQL
+	// error reported to the client
+	StatusErrors map[int]string
+}
+
+// StatusError is returned when the upstream answers with a status code mapped
+// to a GraphQL error in the connector configuration.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// Status returns the status code answered by the upstream
+func (e *StatusError) Status() int {
+	return e.StatusCode
+}
+
+// statusError is returned for the status codes without a mapped message
+type statusError struct {
+	code int
+	url  string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("REST API returned status %d for %s", e.code, e.url)
+}
+
+func (e *statusError) Status() int {
+	return e.code
 }
 
 type restAdapter struct {
@@ -20,7 +102,16 @@ type restAdapter struct {
 	accessToken *string
 	baseUrl     string
 	endpoint    string
+	method      string
+	body        interface{}
+	response    RestResponse
+	path        []string
+	pagination  *RestPagination
+	itemsPath   []string
+	cursorPath  []string
 	auth        bool
+	signer      *sigV4Signer
+	authorizer  requestAuth
 	attr        map[string]interface{}
 	headers     map[string]interface{}
 }
@@ -28,70 +119,378 @@ type restAdapter struct {
 func NewRestAdapter(cfg *types.Config, baseUrl, endpoint string, auth bool, attributes, headers map[string]interface{}) RestAdapter {
 	return &restAdapter{
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: defaultHTTPTimeout,
 		},
 		accessToken: &cfg.AccessToken,
 		baseUrl:     baseUrl,
 		endpoint:    endpoint,
+		method:      http.MethodGet,
 		headers:     headers,
 		attr:        attributes,
 		auth:        auth,
 	}
 }
 
-func (r *restAdapter) GetData(args []AdapterAttribute) (interface{}, error) {
-	route := r.endpoint
-	if re.MatchString(route) {
-		for _, attr := range args {
-			route = strings.ReplaceAll(
-				route,
-				fmt.Sprintf("{%s}", attr.Name),
-				fmt.Sprintf("%v", attr.Value))
+// newRestFromSettings creates a REST adapter from the connector settings
+func newRestFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	headers, err := settings.StringMap("headers")
+	if err != nil {
+		return nil, err
+	}
+	auth, err := authSetting(settings)
+	if err != nil {
+		return nil, err
+	}
+	baseUrl, err := settings.RequiredString("baseUrl")
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := settings.String("endpoint")
+	if err != nil {
+		return nil, err
+	}
+	method, err := settings.String("method")
+	if err != nil {
+		return nil, err
+	}
+
+	var response RestResponse
+	if response.Path, err = settings.String("responsePath"); err != nil {
+		return nil, err
+	}
+	if response.NotFoundAsNull, err = settings.Bool("notFoundAsNull"); err != nil {
+		return nil, err
+	}
+
+	if response.StatusErrors, err = statusErrorsSetting(settings); err != nil {
+		return nil, err
+	}
+
+	cfg := settings.Config
+	if cfg == nil {
+		cfg = &types.Config{}
+	}
+
+	adapter, err := NewRestAdapter(cfg, baseUrl, endpoint, auth == RestAuthBearer, attributes, headers).
+		WithRequest(method, settings.Raw["body"])
+	if err != nil {
+		return nil, settings.wrap(err)
+	}
+
+	if auth == RestAuthSigV4 {
+		var signing SigV4Options
+		if err := settings.Decode(&signing); err != nil {
+			return nil, err
+		}
+		if adapter, err = adapter.WithSigV4(signing); err != nil {
+			return nil, settings.wrap(err)
+		}
+	}
+
+	profile, err := authProfileSetting(settings, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if adapter, err = adapter.WithResponse(response); err != nil {
+		return nil, settings.wrap(err)
+	}
+
+	var options struct {
+		Pagination *RestPagination    `json:"pagination"`
+		HTTP       *HTTPClientOptions `json:"http"`
+	}
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+	if options.HTTP != nil {
+		if adapter, err = adapter.WithHTTPClient(*options.HTTP); err != nil {
+			return nil, settings.wrap(err)
+		}
+	}
+	if options.Pagination != nil {
+		if adapter, err = adapter.WithPagination(*options.Pagination); err != nil {
+			return nil, settings.wrap(err)
+		}
+	}
+
+	// the auth profile is applied last, since the sts profiles start a token
+	// refresh loop that would leak if a later setting failed
+	if profile != nil {
+		adapter, err = adapter.WithAuthProfile(*profile)
+	}
+	return adapter, settings.wrap(err)
+}
+
+// authSetting reads the "auth" setting, which is either a boolean enabling the
+// bearer token or the name of the authentication method
+func authSetting(settings Settings) (string, error) {
+	switch value := settings.Raw["auth"].(type) {
+	case nil:
+		return "", nil
+	case bool:
+		if value {
+			return RestAuthBearer, nil
+		}
+		return "", nil
+	case string:
+		switch auth := strings.ToLower(value); auth {
+		case "", "none":
+			return "", nil
+		case RestAuthBearer, RestAuthSigV4:
+			return auth, nil
+		}
+	}
+	return "", settings.invalid("auth", "expected true, false, bearer or sigv4")
+}
+
+// statusErrorsSetting reads the "statusErrors" setting, which maps status codes to messages
+func statusErrorsSetting(settings Settings) (map[int]string, error) {
+	statusErrors, err := settings.Map("statusErrors")
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[int]string, len(statusErrors))
+	for code, message := range statusErrors {
+		statusCode, err := strconv.Atoi(code)
+		if err != nil {
+			return nil, settings.invalid("statusErrors", "invalid status code %s", code)
+		}
+		messages[statusCode] = fmt.Sprintf("%v", message)
+	}
+	return messages, nil
+}
+
+func (r *restAdapter) WithRequest(method string, body interface{}) (RestAdapter, error) {
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	method = strings.ToUpper(method)
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("unsupported REST method: %s", method)
+	}
+
+	// a body informed as text must still be a valid JSON template
+	if text, ok := body.(string); ok {
+		var tpl interface{}
+		if err := json.Unmarshal([]byte(text), &tpl); err != nil {
+			return nil, fmt.Errorf("invalid REST body template: %v", err)
+		}
+		body = tpl
+	}
+
+	if err := validateTemplate(body); err != nil {
+		return nil, fmt.Errorf("invalid REST body template: %v", err)
+	}
+
+	r.method = method
+	r.body = body
+	return r, nil
+}
+
+func (r *restAdapter) WithResponse(response RestResponse) (RestAdapter, error) {
+	if response.Path != "" {
+		path, err := compilePath(response.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REST response path: %v", err)
 		}
+		r.path = path
+	}
+
+	r.response = response
+	return r, nil
+}
+
+func (r *restAdapter) WithHTTPClient(options HTTPClientOptions) (RestAdapter, error) {
+	client, err := newHTTPClient(r.baseUrl, options)
+	if err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/%s", r.baseUrl, route)
-	req, _ := http.NewRequest("GET", url, nil)
+	r.client = client
+	return r, nil
+}
+
+func (r *restAdapter) WithSigV4(options SigV4Options) (RestAdapter, error) {
+	if options.Service == "" {
+		options.Service = "execute-api"
+	}
+
+	signer, err := newSigV4Signer(options.Region, options.Service, options.AccessKeyId, options.SecretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r.signer = signer
+	r.auth = false
+	return r, nil
+}
+
+func (r *restAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	var body interface{}
+	if r.body != nil {
+		var err error
+		if body, err = renderTemplate(r.body, args); err != nil {
+			return nil, fmt.Errorf("failed to build REST API request body: %v", err)
+		}
+	}
+
+	if r.pagination != nil {
+		return r.paginate(ctx, args, body)
+	}
+
+	data, err := r.do(ctx, args, body)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return r.extract(data), nil
+}
+
+// do sends the request with the body informed, already rendered, and returns
+// the decoded response document. It returns nil when there is no content.
+func (r *restAdapter) do(ctx context.Context, args []AdapterAttribute, body interface{}) (interface{}, error) {
+	url, err := r.url(args)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := r.send(ctx, args, url, body)
+	return data, err
+}
+
+// url renders the route of the request
+func (r *restAdapter) url(args []AdapterAttribute) (string, error) {
+	// the argument values are escaped, so that they cannot change the route
+	tpl, err := ParseTemplate(r.endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to build REST API route: %v", err)
+	}
+	route, err := tpl.RenderURL(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to build REST API route: %v", err)
+	}
+	return fmt.Sprintf("%s/%s", r.baseUrl, route), nil
+}
+
+// send requests the url informed and returns the decoded response document
+// along with the response headers
+func (r *restAdapter) send(ctx context.Context, args []AdapterAttribute, url string, body interface{}) (interface{}, http.Header, error) {
+	var (
+		payload io.Reader
+		encoded []byte
+	)
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode REST API request body: %v", err)
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, url, payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create REST API request %s: %v", url, err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	for key, value := range r.headers {
-		finalValue := value.(string)
-		if re.MatchString(finalValue) {
-			for _, attr := range args {
-				finalValue = strings.ReplaceAll(
-					finalValue,
-					fmt.Sprintf("{%s}", attr.Name),
-					fmt.Sprintf("%v", attr.Value))
-			}
+		header, err := renderText(value.(string), args)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build REST API header %s: %v", key, err)
 		}
-		req.Header.Add(key, finalValue)
+		req.Header.Set(key, header)
 	}
 
 	if r.auth {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *r.accessToken))
 	}
 
+	if r.authorizer != nil {
+		if err := r.authorizer.authorize(ctx, req); err != nil {
+			return nil, nil, fmt.Errorf("failed to authenticate REST API request %s: %w", url, err)
+		}
+	}
+
+	// the signature covers the headers, so it must be the last change to the request
+	if r.signer != nil {
+		if err := r.signer.sign(ctx, req, encoded); err != nil {
+			return nil, nil, fmt.Errorf("failed to sign REST API request %s: %v", url, err)
+		}
+	}
+
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from REST API %s: %v", url, err)
+		return nil, nil, fmt.Errorf("failed to fetch from REST API %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("REST API returned status %d for %s", resp.StatusCode, url)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if resp.StatusCode == http.StatusNotFound && r.response.NotFoundAsNull {
+			return nil, resp.Header, nil
+		}
+		if message, exists := r.response.StatusErrors[resp.StatusCode]; exists {
+			return nil, nil, &StatusError{StatusCode: resp.StatusCode, URL: url, Message: message}
+		}
+		return nil, nil, &statusError{code: resp.StatusCode, url: url}
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	content, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read REST API response: %v", err)
+		return nil, nil, fmt.Errorf("failed to read REST API response: %v", err)
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, resp.Header, nil
 	}
 
-	var data map[string]interface{}
-	if err := json.Unmarshal(body, &data); err != nil {
-		return nil, fmt.Errorf("failed to decode REST API response: %v", err)
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode REST API response: %v", err)
 	}
+	return data, resp.Header, nil
+}
 
-	// validar se exige ou nao data
-	return data["data"], nil
+// extract selects the configured response path. Without a path, the "data"
+// envelope is returned for JSON objects and any other root is returned as is.
+func (r *restAdapter) extract(data interface{}) interface{} {
+	if r.path != nil {
+		return lookupPath(data, r.path)
+	}
+	if object, ok := data.(map[string]interface{}); ok {
+		return object["data"]
+	}
+	return data
+}
+
+// HealthCheck sends a HEAD request to the base URL. Any response below 500
+// means the upstream is reachable, since the base URL is rarely a resource.
+func (r *restAdapter) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, r.baseUrl, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create REST API health check %s: %v", r.baseUrl, err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach REST API %s: %w", r.baseUrl, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return &statusError{code: resp.StatusCode, url: r.baseUrl}
+	}
+	return nil
 }
 
 func (r *restAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
@@ -1,10 +1,13 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/raywall/cloud-service-pack/go/graphql/types"
@@ -110,7 +113,7 @@ func TestRestAdapter_GetData_Success(t *testing.T) {
 		{Name: "userId", Type: "string", Value: "123"},
 	}
 
-	result, err := adapter.GetData(args)
+	result, err := adapter.GetData(context.Background(), args)
 	if err != nil {
 		t.Fatalf("GetData() erro = %v", err)
 	}
@@ -145,7 +148,7 @@ func TestRestAdapter_GetData_WithoutAuth(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, server.URL, "public", false, nil, nil)
 
-	result, err := adapter.GetData([]AdapterAttribute{})
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err != nil {
 		t.Fatalf("GetData() erro = %v", err)
 	}
@@ -169,7 +172,7 @@ func TestRestAdapter_GetData_HTTPError(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, server.URL, "users/123", false, nil, nil)
 
-	_, err := adapter.GetData([]AdapterAttribute{})
+	_, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err == nil {
 		t.Fatal("esperado erro HTTP 404")
 	}
@@ -184,7 +187,7 @@ func TestRestAdapter_GetData_NetworkError(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, "http://invalid-url", "users", false, nil, nil)
 
-	_, err := adapter.GetData([]AdapterAttribute{})
+	_, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err == nil {
 		t.Fatal("esperado erro de rede")
 	}
@@ -200,7 +203,7 @@ func TestRestAdapter_GetData_InvalidJSON(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, server.URL, "users", false, nil, nil)
 
-	_, err := adapter.GetData([]AdapterAttribute{})
+	_, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err == nil {
 		t.Fatal("esperado erro de JSON inválido")
 	}
@@ -224,7 +227,7 @@ func TestRestAdapter_GetData_NoParameters(t *testing.T) {
 	cfg := &types.Config{AccessToken: "test-token"}
 	adapter := NewRestAdapter(cfg, server.URL, "static", false, nil, nil)
 
-	result, err := adapter.GetData([]AdapterAttribute{})
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{})
 	if err != nil {
 		t.Fatalf("GetData() erro = %v", err)
 	}
@@ -233,3 +236,189 @@ func TestRestAdapter_GetData_NoParameters(t *testing.T) {
 		t.Errorf("result = %v, esperado static content", result)
 	}
 }
+
+func TestRestAdapter_GetData_EscapedRoute(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.EscapedPath(), r.URL.RawQuery
+		w.Write([]byte(`{"data": "ok"}`))
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{}
+	adapter := NewRestAdapter(cfg, server.URL, "convenios/{codigo}?canal={canal}", false, nil, nil)
+
+	// os argumentos não alteram a rota nem os parâmetros do upstream
+	_, err := adapter.GetData(context.Background(), []AdapterAttribute{
+		{Name: "codigo", Type: "String", Value: "../admin"},
+		{Name: "canal", Type: "String", Value: "app&admin=true"},
+	})
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+	if path != "/convenios/..%2Fadmin" || query != "canal=app%26admin%3Dtrue" {
+		t.Errorf("path = %s, query = %s", path, query)
+	}
+}
+
+func TestRestAdapter_GetData_PostWithBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %v, esperado POST", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %v, esperado application/json", r.Header.Get("Content-Type"))
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("erro ao decodificar body: %v", err)
+		}
+
+		filter, ok := body["filter"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("filter = %v, esperado objeto", body["filter"])
+		}
+
+		if filter["codigo"] != float64(123) {
+			t.Errorf("codigo = %#v, esperado número 123", filter["codigo"])
+		}
+
+		if filter["ativo"] != true {
+			t.Errorf("ativo = %#v, esperado booleano true", filter["ativo"])
+		}
+
+		if body["descricao"] != "convenio-123" {
+			t.Errorf("descricao = %v, esperado convenio-123", body["descricao"])
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "123"},
+		})
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	adapter, err := NewRestAdapter(cfg, server.URL, "search", false, nil, nil).
+		WithRequest("post", `{"filter": {"codigo": "{codigo}", "ativo": "{ativo}"}, "descricao": "convenio-{codigo}"}`)
+	if err != nil {
+		t.Fatalf("WithRequest() erro = %v", err)
+	}
+
+	args := []AdapterAttribute{
+		{Name: "codigo", Type: "Int", Value: 123},
+		{Name: "ativo", Type: "Boolean", Value: true},
+	}
+
+	result, err := adapter.GetData(context.Background(), args)
+	if err != nil {
+		t.Fatalf("GetData() erro = %v", err)
+	}
+
+	if result.(map[string]interface{})["id"] != "123" {
+		t.Errorf("result = %v, esperado id 123", result)
+	}
+}
+
+func TestRestAdapter_WithRequest_InvalidConfig(t *testing.T) {
+	cfg := &types.Config{AccessToken: "test-token"}
+
+	if _, err := NewRestAdapter(cfg, "url", "endpoint", false, nil, nil).WithRequest("TRACE", nil); err == nil {
+		t.Error("esperado erro para método não suportado")
+	}
+
+	if _, err := NewRestAdapter(cfg, "url", "endpoint", false, nil, nil).WithRequest("POST", "{invalido"); err == nil {
+		t.Error("esperado erro para body inválido")
+	}
+}
+
+func TestRestAdapter_GetData_ResponsePath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/envelope":
+			w.Write([]byte(`{"result": {"items": [{"id": "1"}, {"id": "2"}]}}`))
+		case "/array":
+			w.Write([]byte(`[{"id": "1"}, {"id": "2"}]`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		path     string
+		expected interface{}
+	}{
+		{"json pointer", "envelope", "/result/items/1/id", "2"},
+		{"jsonpath", "envelope", "$.result.items[0].id", "1"},
+		{"caminho inexistente", "envelope", "$.result.missing", nil},
+		{"raiz em array", "array", "$[1].id", "2"},
+		{"array sem caminho", "array", "", []interface{}{
+			map[string]interface{}{"id": "1"},
+			map[string]interface{}{"id": "2"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := NewRestAdapter(cfg, server.URL, tt.endpoint, false, nil, nil).
+				WithResponse(RestResponse{Path: tt.path})
+			if err != nil {
+				t.Fatalf("WithResponse() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), []AdapterAttribute{})
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("result = %v, esperado %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRestAdapter_GetData_StatusHandling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &types.Config{AccessToken: "test-token"}
+	response := RestResponse{
+		NotFoundAsNull: true,
+		StatusErrors:   map[int]string{http.StatusForbidden: "acesso negado pelo upstream"},
+	}
+
+	adapter, err := NewRestAdapter(cfg, server.URL, "missing", false, nil, nil).WithResponse(response)
+	if err != nil {
+		t.Fatalf("WithResponse() erro = %v", err)
+	}
+
+	result, err := adapter.GetData(context.Background(), []AdapterAttribute{})
+	if err != nil || result != nil {
+		t.Errorf("GetData() = %v, %v, esperado nil sem erro para 404", result, err)
+	}
+
+	adapter, _ = NewRestAdapter(cfg, server.URL, "forbidden", false, nil, nil).WithResponse(response)
+
+	_, err = adapter.GetData(context.Background(), []AdapterAttribute{})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("erro = %v, esperado *StatusError", err)
+	}
+
+	if statusErr.StatusCode != http.StatusForbidden || statusErr.Error() != "acesso negado pelo upstream" {
+		t.Errorf("StatusError = %+v, esperado 403 com mensagem configurada", statusErr)
+	}
+}
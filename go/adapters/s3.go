@@ -1,64 +1,292 @@
 package adapters
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"path"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/raywall/cloud-service-pack/go/data/types"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register("s3", newS3FromSettings)
+}
+
+// Object formats decoded by the S3 adapter
+const (
+	S3FormatJSON   = "json"
+	S3FormatYAML   = "yaml"
+	S3FormatCSV    = "csv"
+	S3FormatNDJSON = "ndjson"
 )
 
 type S3Adapter interface {
 	Adapter
+
+	// WithOptions defines how the object is decoded and, optionally, which
+	// record of the object is returned
+	WithOptions(options S3Options) (S3Adapter, error)
+}
+
+// S3Options contains the decoding settings of the S3 adapter
+type S3Options struct {
+	// Format is the content format of the object (json, yaml, csv or ndjson).
+	// When empty, it is detected from the extension of the object key.
+	Format string `json:"format"`
+
+	// Lookup selects a single record of a list of records (e.g. CSV or NDJSON)
+	Lookup *S3Lookup `json:"lookup"`
+}
+
+// S3Lookup selects the first record whose field matches the value template
+type S3Lookup struct {
+	// Field is the column or attribute compared in each record
+	Field string `json:"field"`
+
+	// Value is the template of the expected value (e.g. "{codigoConvenio}")
+	Value string `json:"value"`
+}
+
+// s3API contains the operations of the S3 client used by the adapter
+type s3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
 }
 
 type s3Adapter struct {
-	client *s3.Client
-	bucket string
-	attr   map[string]interface{}
+	client     s3API
+	bucket     string
+	keyPattern string
+	attr       map[string]interface{}
+	options    S3Options
 }
 
-func NewS3Adapter(region, bucket, accessKeyId, secretAccessKey string) S3Adapter {
-	cfg, err := config.LoadDefaultConfig(context.Background(),
-		config.WithRegion(region),
-		config.WithCredentialsProvider(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
-			return aws.Credentials{
-				AccessKeyID:     accessKeyId,
-				SecretAccessKey: secretAccessKey,
-			}, nil
-		})),
-	)
+// NewS3Adapter creates an adapter that reads the objects of a bucket. The
+// static credentials of the AWS options are optional, the default credential
+// chain is used without them.
+func NewS3Adapter(options AWSOptions, bucket, keyPattern string, attributes map[string]interface{}) (S3Adapter, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("the S3 adapter requires the bucket name")
+	}
+
+	cfg, err := loadAWSConfig(context.Background(), options)
 	if err != nil {
-		panic(fmt.Errorf("failed to load AWS config: %v", err))
+		return nil, err
 	}
 
 	return &s3Adapter{
-		client: s3.NewFromConfig(cfg),
-		bucket: bucket,
+		client: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			// emulators such as LocalStack do not resolve virtual hosted buckets
+			o.UsePathStyle = options.Endpoint != ""
+		}),
+		bucket:     bucket,
+		keyPattern: keyPattern,
+		attr:       attributes,
+	}, nil
+}
+
+// newS3FromSettings creates an S3 adapter from the connector settings
+func newS3FromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := settings.RequiredString("bucket")
+	if err != nil {
+		return nil, err
+	}
+
+	// the object key can be informed as a template in the adapter settings
+	key, err := settings.String("key")
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		key = settings.KeyPattern
+	}
+
+	var (
+		awsOptions AWSOptions
+		options    S3Options
+	)
+	if err := settings.Decode(&awsOptions); err != nil {
+		return nil, err
+	}
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewS3Adapter(awsOptions, bucket, key, attributes)
+	if err != nil {
+		return nil, settings.wrap(err)
+	}
+
+	adapter, err = adapter.WithOptions(options)
+	return adapter, settings.wrap(err)
+}
+
+func (s *s3Adapter) WithOptions(options S3Options) (S3Adapter, error) {
+	options.Format = strings.ToLower(options.Format)
+	switch options.Format {
+	case "", S3FormatJSON, S3FormatYAML, S3FormatCSV, S3FormatNDJSON:
+	default:
+		return nil, fmt.Errorf("unsupported S3 object format: %s", options.Format)
+	}
+
+	if options.Lookup != nil {
+		if options.Lookup.Field == "" {
+			return nil, fmt.Errorf("the S3 lookup requires the field name")
+		}
+		if _, err := ParseTemplate(options.Lookup.Value); err != nil {
+			return nil, fmt.Errorf("invalid S3 lookup value: %v", err)
+		}
 	}
+
+	s.options = options
+	return s, nil
 }
 
-func (s *s3Adapter) GetData(args []AdapterAttribute) (interface{}, error) {
-	key := args[0].Name
+func (s *s3Adapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	key, err := renderText(s.keyPattern, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the S3 object key: %v", err)
+	}
 
-	result, err := s.client.GetObject(context.Background(), &s3.GetObjectInput{
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(key),
 	})
+	// a missing object is a null value, so that it can be cached like any other result
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get object %s from S3: %v", key, err)
+		return nil, fmt.Errorf("failed to get object %s from S3: %w", key, err)
 	}
 	defer result.Body.Close()
 
-	var data map[string]interface{}
-	if err := json.NewDecoder(result.Body).Decode(&data); err != nil {
+	content, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 object %s: %w", key, err)
+	}
+
+	data, err := decodeObject(content, s.format(key))
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode S3 object %s: %v", key, err)
 	}
+
+	if s.options.Lookup != nil {
+		return s.lookup(data, args)
+	}
 	return data, nil
 }
 
+// format returns the configured format or the one indicated by the key extension
+func (s *s3Adapter) format(key string) string {
+	if s.options.Format != "" {
+		return s.options.Format
+	}
+
+	switch strings.ToLower(path.Ext(key)) {
+	case ".yaml", ".yml":
+		return S3FormatYAML
+	case ".csv":
+		return S3FormatCSV
+	case ".ndjson", ".jsonl":
+		return S3FormatNDJSON
+	default:
+		return S3FormatJSON
+	}
+}
+
+// lookup returns the first record whose field matches the lookup value
+func (s *s3Adapter) lookup(data interface{}, args []AdapterAttribute) (interface{}, error) {
+	expected, err := renderText(s.options.Lookup.Value, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the S3 lookup value: %v", err)
+	}
+
+	var records []map[string]interface{}
+	switch value := data.(type) {
+	case []map[string]interface{}:
+		records = value
+	case []interface{}:
+		for _, item := range value {
+			if record, ok := item.(map[string]interface{}); ok {
+				records = append(records, record)
+			}
+		}
+	}
+
+	for _, record := range records {
+		if value, exists := record[s.options.Lookup.Field]; exists && fmt.Sprintf("%v", value) == expected {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+// decodeObject converts the object content according to its format
+func decodeObject(content []byte, format string) (interface{}, error) {
+	switch format {
+	// the YAML documents can hold a list of items at the top level, like JSON
+	case S3FormatYAML:
+		var data interface{}
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("error when analyzing yaml: %w", err)
+		}
+		return data, nil
+
+	case S3FormatCSV:
+		return types.ParseStringToCSV(string(content))
+
+	case S3FormatNDJSON:
+		records := make([]interface{}, 0)
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+
+			var record interface{}
+			if err := json.Unmarshal(text, &record); err != nil {
+				return nil, fmt.Errorf("invalid JSON at line %d: %v", line, err)
+			}
+			records = append(records, record)
+		}
+		return records, scanner.Err()
+
+	default:
+		var data interface{}
+		if err := json.Unmarshal(content, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+}
+
+// HealthCheck verifies that the bucket exists and can be accessed
+func (s *s3Adapter) HealthCheck(ctx context.Context) error {
+	if _, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)}); err != nil {
+		return fmt.Errorf("failed to reach S3 bucket %s: %w", s.bucket, err)
+	}
+	return nil
+}
+
 func (r *s3Adapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
 	return getParameters(r.attr, args)
 }
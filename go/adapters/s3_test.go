@@ -0,0 +1,105 @@
+package adapters
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Mock do cliente S3 com objetos em memória
+type mockS3 struct {
+	objects map[string]string
+	keys    []string
+}
+
+func (m *mockS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	key := aws.ToString(params.Key)
+	m.keys = append(m.keys, key)
+	if _, exists := m.objects[key]; !exists {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(m.objects[key]))}, nil
+}
+
+func (m *mockS3) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	m.keys = append(m.keys, aws.ToString(params.Bucket))
+	return &s3.HeadBucketOutput{}, nil
+}
+
+func TestS3Adapter_GetData_Formats(t *testing.T) {
+	client := &mockS3{objects: map[string]string{
+		"convenios/42.json":    `{"codigo": 42}`,
+		"convenios/lista.json": `[{"codigo": 1}, {"codigo": 2}]`,
+		"convenios/42.yaml":    "codigo: 42\nnome: Convenio\n",
+		"convenios/lista.yaml": "- codigo: 1\n- codigo: 2\n",
+		"convenios/todos.csv":  "codigo,nome\n1,Primeiro\n42,Convenio\n",
+		"convenios/todos.ndjson": `{"codigo": 1, "nome": "Primeiro"}
+{"codigo": 42, "nome": "Convenio"}
+`,
+	}}
+
+	args := []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 42}}
+
+	tests := []struct {
+		name     string
+		key      string
+		options  S3Options
+		expected interface{}
+	}{
+		{"json objeto", "convenios/{codigo}.json", S3Options{}, map[string]interface{}{"codigo": float64(42)}},
+		{"json array", "convenios/lista.json", S3Options{}, []interface{}{
+			map[string]interface{}{"codigo": float64(1)},
+			map[string]interface{}{"codigo": float64(2)},
+		}},
+		{"yaml", "convenios/{codigo}.yaml", S3Options{}, map[string]interface{}{"codigo": 42, "nome": "Convenio"}},
+		{"yaml lista", "convenios/lista.yaml", S3Options{}, []interface{}{
+			map[string]interface{}{"codigo": 1},
+			map[string]interface{}{"codigo": 2},
+		}},
+		{"csv com lookup", "convenios/todos.csv", S3Options{
+			Lookup: &S3Lookup{Field: "codigo", Value: "{codigo}"},
+		}, map[string]interface{}{"codigo": "42", "nome": "Convenio"}},
+		{"ndjson com lookup", "convenios/todos.ndjson", S3Options{
+			Lookup: &S3Lookup{Field: "codigo", Value: "{codigo}"},
+		}, map[string]interface{}{"codigo": float64(42), "nome": "Convenio"}},
+		{"lookup sem resultado", "convenios/todos.csv", S3Options{
+			Format: "CSV",
+			Lookup: &S3Lookup{Field: "codigo", Value: "99"},
+		}, nil},
+		{"objeto inexistente", "convenios/{codigo}.csv", S3Options{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := (&s3Adapter{client: client, bucket: "bucket", keyPattern: tt.key}).WithOptions(tt.options)
+			if err != nil {
+				t.Fatalf("WithOptions() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), args)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("result = %#v, esperado %#v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestS3Adapter_WithOptions_InvalidConfig(t *testing.T) {
+	if _, err := (&s3Adapter{}).WithOptions(S3Options{Format: "xml"}); err == nil {
+		t.Error("esperado erro para formato não suportado")
+	}
+
+	if _, err := (&s3Adapter{}).WithOptions(S3Options{Lookup: &S3Lookup{Value: "{codigo}"}}); err == nil {
+		t.Error("esperado erro para lookup sem campo")
+	}
+}
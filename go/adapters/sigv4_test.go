@@ -0,0 +1,137 @@
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// verifySigV4 recalcula a assinatura da requisição recebida com as mesmas
+// credenciais, considerando apenas os cabeçalhos assinados pelo cliente
+func verifySigV4(t *testing.T, r *http.Request, service string) []byte {
+	body, _ := io.ReadAll(r.Body)
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		t.Errorf("X-Amz-Content-Sha256 = %q, esperado %q", r.Header.Get("X-Amz-Content-Sha256"), payloadHash)
+	}
+
+	authorization := r.Header.Get("Authorization")
+	_, signedHeaders, _ := strings.Cut(authorization, "SignedHeaders=")
+	signedHeaders, _, _ = strings.Cut(signedHeaders, ",")
+
+	clone, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	for _, name := range strings.Split(signedHeaders, ";") {
+		if name != "host" {
+			clone.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	clone.ContentLength = r.ContentLength
+
+	signedAt, _ := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	credentials := aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}
+	if err := v4.NewSigner().SignHTTP(context.Background(), credentials, clone, payloadHash, service, "sa-east-1", signedAt); err != nil {
+		t.Fatalf("SignHTTP() erro = %v", err)
+	}
+
+	if clone.Header.Get("Authorization") != authorization {
+		t.Errorf("Authorization = %q, esperado %q", authorization, clone.Header.Get("Authorization"))
+	}
+	return body
+}
+
+func TestRestAdapter_SigV4(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = verifySigV4(t, r, "execute-api")
+		w.Write([]byte(`{"data": {"codigo": 42}}`))
+	}))
+	defer server.Close()
+
+	for _, raw := range []map[string]interface{}{
+		{"endpoint": "convenios/{codigo}?canal=app"},
+		{"endpoint": "convenios", "method": "POST", "body": map[string]interface{}{"codigo": "{codigo}"}},
+	} {
+		raw["baseUrl"] = server.URL
+		raw["auth"] = "sigv4"
+		raw["region"] = "sa-east-1"
+		raw["accessKeyId"] = "AKID"
+		raw["secretAccessKey"] = "SECRET"
+		raw["attr"] = map[string]interface{}{"codigo": "Int!"}
+
+		adapter, err := New("rest", Settings{Raw: raw})
+		if err != nil {
+			t.Fatalf("New() erro = %v", err)
+		}
+
+		params, _ := adapter.GetParameters(map[string]interface{}{"codigo": 42})
+		if _, err := adapter.GetData(context.Background(), params); err != nil {
+			t.Fatalf("GetData() erro = %v", err)
+		}
+	}
+
+	if string(received) != `{"codigo":42}` {
+		t.Errorf("corpo recebido = %s", received)
+	}
+}
+
+func TestGraphQLAdapter_SigV4(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifySigV4(t, r, "appsync")
+		w.Write([]byte(`{"data": {"convenio": {"codigo": 42}}}`))
+	}))
+	defer server.Close()
+
+	adapter, err := New("graphql", Settings{Raw: map[string]interface{}{
+		"baseUrl":         server.URL,
+		"query":           "query { convenio { codigo } }",
+		"auth":            "sigv4",
+		"service":         "appsync",
+		"region":          "sa-east-1",
+		"accessKeyId":     "AKID",
+		"secretAccessKey": "SECRET",
+	}})
+	if err != nil {
+		t.Fatalf("New() erro = %v", err)
+	}
+
+	if _, err := adapter.GetData(context.Background(), nil); err != nil {
+		t.Errorf("GetData() erro = %v", err)
+	}
+}
+
+func TestAuthSetting(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, ""},
+		{false, ""},
+		{true, RestAuthBearer},
+		{"Bearer", RestAuthBearer},
+		{"none", ""},
+		{"sigv4", RestAuthSigV4},
+	}
+
+	for _, tt := range tests {
+		auth, err := authSetting(Settings{Raw: map[string]interface{}{"auth": tt.value}})
+		if err != nil || auth != tt.expected {
+			t.Errorf("authSetting(%v) = %q, erro = %v, esperado %q", tt.value, auth, err, tt.expected)
+		}
+	}
+
+	var configErr *ConfigError
+	if _, err := New("rest", Settings{Raw: map[string]interface{}{"baseUrl": "http://localhost", "auth": "oauth"}}); !errors.As(err, &configErr) {
+		t.Errorf("New() erro = %v, esperado *ConfigError", err)
+	}
+}
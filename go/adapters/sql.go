@@ -0,0 +1,263 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("sql", newSQLFromSettings)
+}
+
+// SQL result modes
+const (
+	SQLResultRow  = "row"
+	SQLResultList = "list"
+)
+
+type SQLAdapter interface {
+	Adapter
+}
+
+// SQLOptions contains the connection, pool and query settings of the SQL adapter.
+// The database/sql driver (e.g. pgx, mysql or sqlite) must be registered by the
+// application with a blank import.
+type SQLOptions struct {
+	// Driver is the name of the registered database/sql driver
+	Driver string `json:"driver"`
+
+	// DSN is the data source name used to open the connection
+	DSN string `json:"dsn"`
+
+	// Query is the query template. Each {attr} placeholder is sent to the
+	// database as a bind parameter, never concatenated to the statement.
+	Query string `json:"query"`
+
+	// Placeholder is the bind parameter style: "?", "$" ($1, $2...) or "@p"
+	// (@p1, @p2...). When empty, it is chosen from the driver name.
+	Placeholder string `json:"placeholder"`
+
+	// Result indicates whether the first row (row) or all rows (list) are returned
+	Result string `json:"result"`
+
+	// MaxOpenConns, MaxIdleConns, ConnMaxLifetime and ConnMaxIdleTime are the
+	// connection pool settings (durations like "5m")
+	MaxOpenConns    int    `json:"maxOpenConns"`
+	MaxIdleConns    int    `json:"maxIdleConns"`
+	ConnMaxLifetime string `json:"connMaxLifetime"`
+	ConnMaxIdleTime string `json:"connMaxIdleTime"`
+}
+
+type sqlAdapter struct {
+	db      *sql.DB
+	query   *Template
+	options SQLOptions
+	attr    map[string]interface{}
+}
+
+// newSQLFromSettings creates a SQL adapter from the connector settings
+func newSQLFromSettings(settings Settings) (Adapter, error) {
+	attributes, err := settings.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	var options SQLOptions
+	if err := settings.Decode(&options); err != nil {
+		return nil, err
+	}
+
+	adapter, err := NewSQLAdapter(options, attributes)
+	return adapter, settings.wrap(err)
+}
+
+// NewSQLAdapter opens the connection pool of the database and validates the query template
+func NewSQLAdapter(options SQLOptions, attributes map[string]interface{}) (SQLAdapter, error) {
+	if options.Driver == "" || options.DSN == "" {
+		return nil, fmt.Errorf("the SQL adapter requires the driver and the dsn")
+	}
+
+	if strings.TrimSpace(options.Query) == "" {
+		return nil, fmt.Errorf("the SQL adapter requires a query")
+	}
+
+	query, err := ParseTemplate(options.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL query template: %v", err)
+	}
+
+	options.Result = strings.ToLower(options.Result)
+	switch options.Result {
+	case "":
+		options.Result = SQLResultRow
+	case SQLResultRow, SQLResultList:
+	default:
+		return nil, fmt.Errorf("unsupported SQL result mode: %s", options.Result)
+	}
+
+	if options.Placeholder == "" {
+		switch options.Driver {
+		case "postgres", "pgx":
+			options.Placeholder = "$"
+		case "sqlserver", "mssql":
+			options.Placeholder = "@p"
+		default:
+			options.Placeholder = "?"
+		}
+	}
+	switch options.Placeholder {
+	case "?", "$", "@p":
+	default:
+		return nil, fmt.Errorf("unsupported SQL placeholder style: %s", options.Placeholder)
+	}
+
+	db, err := sql.Open(options.Driver, options.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s database: %v", options.Driver, err)
+	}
+
+	if options.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(options.MaxOpenConns)
+	}
+	if options.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(options.MaxIdleConns)
+	}
+	for _, setting := range []struct {
+		value string
+		apply func(time.Duration)
+	}{
+		{options.ConnMaxLifetime, db.SetConnMaxLifetime},
+		{options.ConnMaxIdleTime, db.SetConnMaxIdleTime},
+	} {
+		if setting.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(setting.value)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("invalid SQL pool duration %q: %v", setting.value, err)
+		}
+		setting.apply(duration)
+	}
+
+	return &sqlAdapter{
+		db:      db,
+		query:   query,
+		options: options,
+		attr:    attributes,
+	}, nil
+}
+
+func (s *sqlAdapter) GetData(ctx context.Context, args []AdapterAttribute) (interface{}, error) {
+	statement, params, err := s.bind(args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute SQL query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SQL columns: %v", err)
+	}
+
+	result := make([]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to read SQL row: %v", err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if content, ok := values[i].([]byte); ok {
+				row[column] = string(content)
+			} else {
+				row[column] = values[i]
+			}
+		}
+
+		if s.options.Result == SQLResultRow {
+			return row, rows.Err()
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read SQL rows: %v", err)
+	}
+
+	if s.options.Result == SQLResultRow {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// bind replaces the placeholders of the query template by bind parameters,
+// returning the statement and the parameter values in order
+func (s *sqlAdapter) bind(args []AdapterAttribute) (string, []interface{}, error) {
+	var (
+		statement strings.Builder
+		params    = make([]interface{}, 0, len(s.query.placeholders))
+		last      int
+	)
+
+	for i, field := range s.query.placeholders {
+		var value interface{}
+		if len(field.filters) == 0 {
+			raw, found := lookupAttribute(args, field.name)
+			if !found {
+				return "", nil, fmt.Errorf("missing value for placeholder %s", field.token)
+			}
+			value = raw
+		} else {
+			text, err := field.render(args)
+			if err != nil {
+				return "", nil, err
+			}
+			value = text
+		}
+		params = append(params, value)
+
+		statement.WriteString(s.query.text[last:field.start])
+		switch s.options.Placeholder {
+		case "?":
+			statement.WriteString("?")
+		default:
+			statement.WriteString(fmt.Sprintf("%s%d", s.options.Placeholder, i+1))
+		}
+		last = field.end
+	}
+	statement.WriteString(s.query.text[last:])
+
+	return statement.String(), params, nil
+}
+
+// HealthCheck verifies that a connection to the database can be established
+func (s *sqlAdapter) HealthCheck(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection pool of the database
+func (s *sqlAdapter) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlAdapter) GetParameters(args map[string]interface{}) ([]AdapterAttribute, error) {
+	return getParameters(s.attr, args)
+}
@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestDatabase cria um banco SQLite temporário com dados de convênios
+func newTestDatabase(t *testing.T) string {
+	dsn := filepath.Join(t.TempDir(), "convenios.db")
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("erro ao abrir sqlite: %v", err)
+	}
+	defer db.Close()
+
+	for _, statement := range []string{
+		"CREATE TABLE convenios (codigo INTEGER, produto TEXT, nome TEXT)",
+		"INSERT INTO convenios VALUES (1, 'CONSIGNADO', 'Primeiro'), (2, 'CONSIGNADO', 'Segundo'), (3, 'CARTAO', 'Terceiro')",
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("erro ao preparar sqlite: %v", err)
+		}
+	}
+	return dsn
+}
+
+func TestSQLAdapter_GetData(t *testing.T) {
+	dsn := newTestDatabase(t)
+
+	tests := []struct {
+		name     string
+		options  SQLOptions
+		args     []AdapterAttribute
+		expected interface{}
+	}{
+		{
+			name: "linha única",
+			options: SQLOptions{
+				Query: "SELECT codigo, nome FROM convenios WHERE codigo = {codigo}",
+			},
+			args:     []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 2}},
+			expected: map[string]interface{}{"codigo": int64(2), "nome": "Segundo"},
+		},
+		{
+			name: "lista com filtro de formatação",
+			options: SQLOptions{
+				Query:  "SELECT nome FROM convenios WHERE produto = {produto|upper} ORDER BY codigo",
+				Result: SQLResultList,
+			},
+			args: []AdapterAttribute{{Name: "produto", Type: "String", Value: "consignado"}},
+			expected: []interface{}{
+				map[string]interface{}{"nome": "Primeiro"},
+				map[string]interface{}{"nome": "Segundo"},
+			},
+		},
+		{
+			name: "sem resultados",
+			options: SQLOptions{
+				Query: "SELECT nome FROM convenios WHERE codigo = {codigo}",
+			},
+			args:     []AdapterAttribute{{Name: "codigo", Type: "Int", Value: 99}},
+			expected: nil,
+		},
+		{
+			name: "parâmetros não são concatenados",
+			options: SQLOptions{
+				Query:  "SELECT nome FROM convenios WHERE nome = {nome}",
+				Result: SQLResultList,
+			},
+			args:     []AdapterAttribute{{Name: "nome", Type: "String", Value: "x' OR '1'='1"}},
+			expected: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Driver = "sqlite"
+			tt.options.DSN = dsn
+			tt.options.MaxOpenConns = 2
+			tt.options.ConnMaxLifetime = "1m"
+
+			adapter, err := NewSQLAdapter(tt.options, nil)
+			if err != nil {
+				t.Fatalf("NewSQLAdapter() erro = %v", err)
+			}
+
+			result, err := adapter.GetData(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("GetData() erro = %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("result = %#v, esperado %#v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSQLAdapter_Bind(t *testing.T) {
+	adapter, err := NewSQLAdapter(SQLOptions{
+		Driver:      "sqlite",
+		DSN:         ":memory:",
+		Placeholder: "$",
+		Query:       "SELECT * FROM t WHERE a = {a} AND b = {b|default:x}",
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewSQLAdapter() erro = %v", err)
+	}
+
+	statement, params, err := adapter.(*sqlAdapter).bind([]AdapterAttribute{{Name: "a", Type: "Int", Value: 1}})
+	if err != nil {
+		t.Fatalf("bind() erro = %v", err)
+	}
+
+	if statement != "SELECT * FROM t WHERE a = $1 AND b = $2" {
+		t.Errorf("statement = %v", statement)
+	}
+
+	if !reflect.DeepEqual(params, []interface{}{1, "x"}) {
+		t.Errorf("params = %v, esperado [1 x]", params)
+	}
+
+	if _, _, err := adapter.(*sqlAdapter).bind(nil); err == nil {
+		t.Error("esperado erro para placeholder obrigatório ausente")
+	}
+}
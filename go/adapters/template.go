@@ -0,0 +1,306 @@
+package adapters
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// templatePlaceholder matches {name} placeholders followed by optional filters,
+// such as {codigo|pad:6}, {produto|upper} or {status|default:ATIVO}
+var templatePlaceholder *regexp.Regexp = regexp.MustCompile(`{([A-Za-z_][\w.]*)((?:\|[^{}|]+)*)}`)
+
+// dateLayouts are the layouts accepted when a text value is formatted as a date
+var dateLayouts = []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// Template is a text with {attr} placeholders shared by every adapter to build
+// keys, paths, headers and bodies from the GraphQL arguments.
+//
+// The filters available after the attribute name are:
+//   - default:value uses the value when the argument was not informed
+//   - upper, lower and trim change the text of the value
+//   - pad:n fills the value with zeros on the left up to n characters
+//   - date:layout formats a date using a Go layout (e.g. date:2006-01-02)
+//   - urlencode escapes the value to be used in a URL path or query string
+//     (RenderURL, used by the REST routes, escapes every value by default)
+//
+// An argument without value and without a default results in an error.
+type Template struct {
+	text         string
+	placeholders []templateField
+}
+
+type templateField struct {
+	token      string
+	name       string
+	filters    []templateFilter
+	start, end int
+}
+
+type templateFilter struct {
+	name string
+	arg  string
+}
+
+// ParseTemplate validates the placeholders and filters of the text
+func ParseTemplate(text string) (*Template, error) {
+	tpl := &Template{text: text}
+	if !re.MatchString(text) {
+		return tpl, nil
+	}
+
+	for _, index := range templatePlaceholder.FindAllStringSubmatchIndex(text, -1) {
+		match := make([]string, len(index)/2)
+		for i := range match {
+			match[i] = text[index[2*i]:index[2*i+1]]
+		}
+		field := templateField{token: match[0], name: match[1], start: index[0], end: index[1]}
+
+		for _, filter := range strings.Split(match[2], "|")[1:] {
+			name, arg, _ := strings.Cut(filter, ":")
+			switch name {
+			case "upper", "lower", "trim", "urlencode":
+			case "default", "date":
+			case "pad":
+				if _, err := strconv.Atoi(arg); err != nil {
+					return nil, fmt.Errorf("invalid pad size in %s: %s", match[0], arg)
+				}
+			default:
+				return nil, fmt.Errorf("unknown template function %q in %s", name, match[0])
+			}
+			field.filters = append(field.filters, templateFilter{name: name, arg: arg})
+		}
+		tpl.placeholders = append(tpl.placeholders, field)
+	}
+	return tpl, nil
+}
+
+// Render replaces all the placeholders by the formatted argument values
+func (t *Template) Render(args []AdapterAttribute) (string, error) {
+	return t.render(args, nil)
+}
+
+// RenderURL works like Render, but escapes the values so that an argument cannot
+// change the route of a URL: values before the "?" are escaped as a path segment
+// and values after it as a query value. Placeholders with the urlencode filter
+// are already escaped and are kept unchanged.
+func (t *Template) RenderURL(args []AdapterAttribute) (string, error) {
+	query := strings.Index(t.text, "?")
+	return t.render(args, func(field templateField, value string) string {
+		for _, filter := range field.filters {
+			if filter.name == "urlencode" {
+				return value
+			}
+		}
+		if query >= 0 && field.start > query {
+			return url.QueryEscape(value)
+		}
+		return escapePathSegment(value)
+	})
+}
+
+// render replaces the placeholders, escaping the values when escape is informed
+func (t *Template) render(args []AdapterAttribute, escape func(templateField, string) string) (string, error) {
+	var (
+		text strings.Builder
+		last int
+	)
+	for _, field := range t.placeholders {
+		value, err := field.render(args)
+		if err != nil {
+			return "", err
+		}
+		if escape != nil {
+			value = escape(field, value)
+		}
+		text.WriteString(t.text[last:field.start])
+		text.WriteString(value)
+		last = field.end
+	}
+	text.WriteString(t.text[last:])
+	return text.String(), nil
+}
+
+// escapePathSegment escapes the value as a single path segment. The dot segments
+// are escaped as well, since "." and ".." would still move along the path.
+func escapePathSegment(value string) string {
+	switch value {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(value)
+}
+
+// Value works like Render, but a template made of a single placeholder without
+// filters returns the raw argument value, so numbers and booleans keep their type.
+func (t *Template) Value(args []AdapterAttribute) (interface{}, error) {
+	if len(t.placeholders) == 1 && t.placeholders[0].token == t.text && len(t.placeholders[0].filters) == 0 {
+		value, found := lookupAttribute(args, t.placeholders[0].name)
+		if !found {
+			return nil, fmt.Errorf("missing value for placeholder %s", t.text)
+		}
+		return value, nil
+	}
+	return t.Render(args)
+}
+
+// Names returns the names of the attributes used by the template
+func (t *Template) Names() []string {
+	names := make([]string, len(t.placeholders))
+	for i, field := range t.placeholders {
+		names[i] = field.name
+	}
+	return names
+}
+
+func (f templateField) render(args []AdapterAttribute) (string, error) {
+	value, found := lookupAttribute(args, f.name)
+
+	var text string
+	if found {
+		text = fmt.Sprintf("%v", value)
+	}
+
+	for _, filter := range f.filters {
+		switch filter.name {
+		case "default":
+			if !found || text == "" {
+				text, found = filter.arg, true
+			}
+		case "upper":
+			text = strings.ToUpper(text)
+		case "lower":
+			text = strings.ToLower(text)
+		case "trim":
+			text = strings.TrimSpace(text)
+		case "urlencode":
+			text = url.PathEscape(text)
+		case "pad":
+			size, _ := strconv.Atoi(filter.arg)
+			if len(text) < size {
+				text = strings.Repeat("0", size-len(text)) + text
+			}
+		case "date":
+			if !found {
+				continue
+			}
+			date, err := toTime(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid date for placeholder %s: %v", f.token, err)
+			}
+			text = date.Format(filter.arg)
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("missing value for placeholder %s", f.token)
+	}
+	return text, nil
+}
+
+// lookupAttribute returns the value of the attribute, reporting whether it was informed
+func lookupAttribute(args []AdapterAttribute, name string) (interface{}, bool) {
+	for _, attr := range args {
+		if attr.Name == name {
+			return attr.Value, attr.Value != nil
+		}
+	}
+	return nil, false
+}
+
+// toTime converts dates informed as time.Time, text or unix timestamps
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case int:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case float64:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case string:
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, v); err == nil {
+				return date, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date value %v", value)
+}
+
+// renderText parses and renders a template in a single step
+func renderText(text string, args []AdapterAttribute) (string, error) {
+	tpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	return tpl.Render(args)
+}
+
+// renderTemplate walks a decoded JSON template (maps, slices and strings) and
+// replaces its {attr} placeholders. A string made of a single placeholder is
+// replaced by the raw attribute value, so numbers and booleans keep their type.
+func renderTemplate(tpl interface{}, args []AdapterAttribute) (interface{}, error) {
+	switch value := tpl.(type) {
+	case string:
+		parsed, err := ParseTemplate(value)
+		if err != nil {
+			return nil, err
+		}
+		return parsed.Value(args)
+
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result, err := renderTemplate(item, args)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = result
+		}
+		return rendered, nil
+
+	case []interface{}:
+		rendered := make([]interface{}, len(value))
+		for i, item := range value {
+			result, err := renderTemplate(item, args)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = result
+		}
+		return rendered, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// validateTemplate checks the placeholders of every string found in a
+// decoded JSON template
+func validateTemplate(tpl interface{}) error {
+	switch value := tpl.(type) {
+	case string:
+		_, err := ParseTemplate(value)
+		return err
+	case map[string]interface{}:
+		for _, item := range value {
+			if err := validateTemplate(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := validateTemplate(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package adapters
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTemplate_Render(t *testing.T) {
+	args := []AdapterAttribute{
+		{Name: "codigoConvenio", Type: "Int", Value: 42},
+		{Name: "produto", Type: "String", Value: "consignado"},
+		{Name: "data", Type: "String", Value: "2025-03-09T10:00:00Z"},
+		{Name: "nome", Type: "String", Value: "joão silva"},
+		{Name: "vazio", Type: "String", Value: nil},
+	}
+
+	tests := []struct {
+		name        string
+		template    string
+		expected    string
+		expectError bool
+	}{
+		{"texto sem placeholders", "static-key", "static-key", false},
+		{"vários placeholders", "CVN_{codigoConvenio}_{produto}", "CVN_42_consignado", false},
+		{"placeholder repetido", "{produto}/{produto}", "consignado/consignado", false},
+		{"zero padding", "CVN_{codigoConvenio|pad:6}", "CVN_000042", false},
+		{"maiúsculas", "{produto|upper}", "CONSIGNADO", false},
+		{"minúsculas", "{produto|upper|lower}", "consignado", false},
+		{"data formatada", "{data|date:20060102}", "20250309", false},
+		{"url encode", "users/{nome|urlencode}", "users/jo%C3%A3o%20silva", false},
+		{"valor padrão", "{vazio|default:ATIVO}", "ATIVO", false},
+		{"valor padrão de argumento ausente", "{status|default:ativo|upper}", "ATIVO", false},
+		{"placeholder obrigatório ausente", "CVN_{codigoConvenio}_{status}", "", true},
+		{"valor nulo obrigatório", "{vazio}", "", true},
+		{"data inválida", "{produto|date:2006}", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl, err := ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() erro = %v", err)
+			}
+
+			result, err := tpl.Render(args)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("Render() erro = %v, esperado erro %v", err, tt.expectError)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Render() = %v, esperado %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTemplate_RenderURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		value    string
+		expected string
+	}{
+		{"segmento com barra", "convenios/{codigo}", "../admin", "convenios/..%2Fadmin"},
+		{"segmento com consulta", "convenios/{codigo}", "a?x=y", "convenios/a%3Fx=y"},
+		{"segmento ponto ponto", "convenios/{codigo}/parcelas", "..", "convenios/%2E%2E/parcelas"},
+		{"valor de consulta", "convenios?codigo={codigo}", "1&admin=true", "convenios?codigo=1%26admin%3Dtrue"},
+		{"urlencode explícito", "convenios/{codigo|urlencode}", "joão silva", "convenios/jo%C3%A3o%20silva"},
+		{"valor simples", "convenios/{codigo}", "42", "convenios/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl, err := ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() erro = %v", err)
+			}
+
+			result, err := tpl.RenderURL([]AdapterAttribute{{Name: "codigo", Type: "String", Value: tt.value}})
+			if err != nil {
+				t.Fatalf("RenderURL() erro = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("RenderURL() = %v, esperado %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTemplate_InvalidFunctions(t *testing.T) {
+	for _, template := range []string{"{codigo|reverse}", "{codigo|pad:x}"} {
+		if _, err := ParseTemplate(template); err == nil {
+			t.Errorf("ParseTemplate(%q) esperado erro", template)
+		}
+	}
+}
+
+func TestRenderTemplate_PreservesTypes(t *testing.T) {
+	args := []AdapterAttribute{
+		{Name: "codigo", Type: "Int", Value: 7},
+		{Name: "ativo", Type: "Boolean", Value: false},
+		{Name: "inicio", Type: "String", Value: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tpl := map[string]interface{}{
+		"codigo": "{codigo}",
+		"ativo":  "{ativo}",
+		"chave":  "CVN-{codigo|pad:3}",
+		"itens":  []interface{}{"{codigo}", "{inicio|date:2006-01-02}", 10.5},
+	}
+
+	result, err := renderTemplate(tpl, args)
+	if err != nil {
+		t.Fatalf("renderTemplate() erro = %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"codigo": 7,
+		"ativo":  false,
+		"chave":  "CVN-007",
+		"itens":  []interface{}{7, "2025-01-02", 10.5},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("renderTemplate() = %#v, esperado %#v", result, expected)
+	}
+}
@@ -77,7 +77,9 @@ func (tm *SelfControlledToken) GetToken() (string, error) {
 
 // Stop interrupts the self controlled token service.
 func (tm *SelfControlledToken) Stop() {
+	tm.mutex.Lock()
 	tm.refreshing = false
+	tm.mutex.Unlock()
 	tm.cancelFunc()
 }
 
@@ -160,6 +162,7 @@ func (tm *SelfControlledToken) refreshToken() error {
 	// Update the token with lock for thread safety.
 	tm.mutex.Lock()
 	*tm.outputAccessToken = *resp.AccessToken
+	tm.principal = resp
 	tm.mutex.Unlock()
 
 	return nil
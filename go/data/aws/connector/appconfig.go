@@ -0,0 +1,163 @@
+package connector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+)
+
+// maxAppConfigProfiles limita os perfis mantidos em memória, já que o nome do perfil pode
+// depender dos argumentos da consulta
+const maxAppConfigProfiles = 100
+
+type AppConfigResource interface {
+	StartConfigurationSessionWithContext(ctx aws.Context, input *appconfigdata.StartConfigurationSessionInput, opts ...request.Option) (*appconfigdata.StartConfigurationSessionOutput, error)
+	GetLatestConfigurationWithContext(ctx aws.Context, input *appconfigdata.GetLatestConfigurationInput, opts ...request.Option) (*appconfigdata.GetLatestConfigurationOutput, error)
+}
+
+// AppConfigCloudContext implementa CloudContext para AWS AppConfig
+type AppConfigCloudContext struct {
+	svc AppConfigResource
+
+	mu       sync.Mutex
+	profiles map[string]*appConfigProfile
+}
+
+// appConfigProfile mantém o token da sessão de um perfil e a última configuração recebida,
+// já que o AppConfig só devolve o conteúdo quando ele foi alterado. O conteúdo é reutilizado
+// até o intervalo de consulta informado pelo AppConfig, e apenas uma consulta por perfil
+// fica em andamento, já que cada token só pode ser usado uma vez.
+type appConfigProfile struct {
+	token    *string
+	content  *string
+	nextPoll time.Time
+	polling  chan struct{}
+	used     time.Time
+}
+
+func NewAppConfigContext(sess *session.Session) *AppConfigCloudContext {
+	return &AppConfigCloudContext{
+		svc:      appconfigdata.New(sess),
+		profiles: make(map[string]*appConfigProfile),
+	}
+}
+
+// GetValue obtém a versão implantada do perfil de configuração de uma aplicação e ambiente
+func (ctx *AppConfigCloudContext) GetValue(application, environment, profile string) (*string, error) {
+	return ctx.GetValueWithContext(aws.BackgroundContext(), application, environment, profile)
+}
+
+// GetValueWithContext obtém a versão implantada do perfil de configuração, respeitando o
+// cancelamento do contexto. As chamadas concorrentes recebem o último conteúdo enquanto
+// uma nova versão é consultada, ou aguardam a primeira consulta do perfil.
+func (ctx *AppConfigCloudContext) GetValueWithContext(awsCtx aws.Context, application, environment, profile string) (*string, error) {
+	key := fmt.Sprintf("%s/%s/%s", application, environment, profile)
+
+	for {
+		ctx.mu.Lock()
+		state, exists := ctx.profiles[key]
+		if !exists {
+			if len(ctx.profiles) >= maxAppConfigProfiles {
+				ctx.evict()
+			}
+			state = &appConfigProfile{}
+			ctx.profiles[key] = state
+		}
+		state.used = time.Now()
+
+		if state.content != nil && (state.polling != nil || time.Now().Before(state.nextPoll)) {
+			value := *state.content
+			ctx.mu.Unlock()
+			return &value, nil
+		}
+
+		if polling := state.polling; polling != nil {
+			ctx.mu.Unlock()
+			select {
+			case <-polling:
+				continue
+			case <-awsCtx.Done():
+				return nil, awsCtx.Err()
+			}
+		}
+
+		done := make(chan struct{})
+		state.polling = done
+		token := state.token
+		ctx.mu.Unlock()
+
+		result, err := ctx.poll(awsCtx, application, environment, profile, token)
+
+		ctx.mu.Lock()
+		state.polling = nil
+		if err != nil {
+			// o token não pode ser reutilizado após uma falha, a próxima consulta inicia uma nova sessão
+			state.token = nil
+		} else {
+			state.token = result.NextPollConfigurationToken
+			state.nextPoll = time.Now().Add(time.Duration(aws.Int64Value(result.NextPollIntervalInSeconds)) * time.Second)
+			if len(result.Configuration) > 0 || state.content == nil {
+				content := string(result.Configuration)
+				state.content = &content
+			}
+		}
+
+		var value string
+		if state.content != nil {
+			value = *state.content
+		}
+		ctx.mu.Unlock()
+		close(done)
+
+		if err != nil {
+			return nil, err
+		}
+		return &value, nil
+	}
+}
+
+// evict remove o perfil usado há mais tempo entre os que não têm uma consulta em andamento.
+// O lock deve estar adquirido.
+func (ctx *AppConfigCloudContext) evict() {
+	var (
+		oldest string
+		used   time.Time
+	)
+	for key, state := range ctx.profiles {
+		if state.polling == nil && (oldest == "" || state.used.Before(used)) {
+			oldest, used = key, state.used
+		}
+	}
+	if oldest != "" {
+		delete(ctx.profiles, oldest)
+	}
+}
+
+// poll consulta a configuração mais recente com o token informado, iniciando uma nova sessão
+// quando ainda não há um token para o perfil
+func (ctx *AppConfigCloudContext) poll(awsCtx aws.Context, application, environment, profile string, token *string) (*appconfigdata.GetLatestConfigurationOutput, error) {
+	if token == nil {
+		session, err := ctx.svc.StartConfigurationSessionWithContext(awsCtx, &appconfigdata.StartConfigurationSessionInput{
+			ApplicationIdentifier:          aws.String(application),
+			EnvironmentIdentifier:          aws.String(environment),
+			ConfigurationProfileIdentifier: aws.String(profile),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error when starting AppConfig session: %w", err)
+		}
+		token = session.InitialConfigurationToken
+	}
+
+	result, err := ctx.svc.GetLatestConfigurationWithContext(awsCtx, &appconfigdata.GetLatestConfigurationInput{
+		ConfigurationToken: token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining AppConfig configuration: %w", err)
+	}
+	return result, nil
+}
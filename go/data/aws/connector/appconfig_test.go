@@ -0,0 +1,136 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para AppConfig
+type mockAppConfigClient struct {
+	mock.Mock
+}
+
+func (m *mockAppConfigClient) StartConfigurationSessionWithContext(ctx aws.Context, input *appconfigdata.StartConfigurationSessionInput, opts ...request.Option) (*appconfigdata.StartConfigurationSessionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*appconfigdata.StartConfigurationSessionOutput), args.Error(1)
+}
+
+func (m *mockAppConfigClient) GetLatestConfigurationWithContext(ctx aws.Context, input *appconfigdata.GetLatestConfigurationInput, opts ...request.Option) (*appconfigdata.GetLatestConfigurationOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*appconfigdata.GetLatestConfigurationOutput), args.Error(1)
+}
+
+func TestAppConfigCloudContext_GetValue(t *testing.T) {
+	t.Run("Get configuration and reuse the session", func(t *testing.T) {
+		mockAppConfig := new(mockAppConfigClient)
+		ctx := &AppConfigCloudContext{
+			svc:      mockAppConfig,
+			profiles: make(map[string]*appConfigProfile),
+		}
+
+		// Preparar mock para AppConfig, a segunda consulta não traz alterações
+		mockAppConfig.On("StartConfigurationSessionWithContext", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{
+			InitialConfigurationToken: aws.String("token-1"),
+		}, nil).Once()
+		mockAppConfig.On("GetLatestConfigurationWithContext", &appconfigdata.GetLatestConfigurationInput{
+			ConfigurationToken: aws.String("token-1"),
+		}).Return(&appconfigdata.GetLatestConfigurationOutput{
+			Configuration:              []byte(`{"limite": 10}`),
+			NextPollConfigurationToken: aws.String("token-2"),
+		}, nil).Once()
+		mockAppConfig.On("GetLatestConfigurationWithContext", &appconfigdata.GetLatestConfigurationInput{
+			ConfigurationToken: aws.String("token-2"),
+		}).Return(&appconfigdata.GetLatestConfigurationOutput{
+			NextPollConfigurationToken: aws.String("token-3"),
+		}, nil).Once()
+
+		// Executar GetValue duas vezes
+		first, err := ctx.GetValue("consignado", "prod", "limites")
+		assert.NoError(t, err)
+		second, err := ctx.GetValue("consignado", "prod", "limites")
+		assert.NoError(t, err)
+
+		// Verificar resultados
+		assert.Equal(t, `{"limite": 10}`, *first)
+		assert.Equal(t, `{"limite": 10}`, *second)
+		mockAppConfig.AssertExpectations(t)
+	})
+
+	t.Run("Reuse the configuration until the poll interval", func(t *testing.T) {
+		mockAppConfig := new(mockAppConfigClient)
+		ctx := &AppConfigCloudContext{
+			svc:      mockAppConfig,
+			profiles: make(map[string]*appConfigProfile),
+		}
+
+		// Preparar mock para AppConfig, apenas uma consulta é esperada dentro do intervalo
+		mockAppConfig.On("StartConfigurationSessionWithContext", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{
+			InitialConfigurationToken: aws.String("token-1"),
+		}, nil).Once()
+		mockAppConfig.On("GetLatestConfigurationWithContext", mock.Anything).Return(&appconfigdata.GetLatestConfigurationOutput{
+			Configuration:              []byte(`{"limite": 10}`),
+			NextPollConfigurationToken: aws.String("token-2"),
+			NextPollIntervalInSeconds:  aws.Int64(60),
+		}, nil).Once()
+
+		for i := 0; i < 3; i++ {
+			value, err := ctx.GetValue("consignado", "prod", "limites")
+			assert.NoError(t, err)
+			assert.Equal(t, `{"limite": 10}`, *value)
+		}
+		mockAppConfig.AssertExpectations(t)
+	})
+
+	t.Run("Start a new session after a failure", func(t *testing.T) {
+		mockAppConfig := new(mockAppConfigClient)
+		ctx := &AppConfigCloudContext{
+			svc:      mockAppConfig,
+			profiles: make(map[string]*appConfigProfile),
+		}
+
+		mockAppConfig.On("StartConfigurationSessionWithContext", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{
+			InitialConfigurationToken: aws.String("token-1"),
+		}, nil).Twice()
+		mockAppConfig.On("GetLatestConfigurationWithContext", mock.Anything).Return((*appconfigdata.GetLatestConfigurationOutput)(nil), errors.New("throttled")).Once()
+		mockAppConfig.On("GetLatestConfigurationWithContext", mock.Anything).Return(&appconfigdata.GetLatestConfigurationOutput{
+			Configuration:              []byte(`{"limite": 10}`),
+			NextPollConfigurationToken: aws.String("token-2"),
+		}, nil).Once()
+
+		_, err := ctx.GetValue("consignado", "prod", "limites")
+		assert.Error(t, err)
+		value, err := ctx.GetValue("consignado", "prod", "limites")
+		assert.NoError(t, err)
+		assert.Equal(t, `{"limite": 10}`, *value)
+		mockAppConfig.AssertExpectations(t)
+	})
+
+	t.Run("Limit the profiles kept in memory", func(t *testing.T) {
+		mockAppConfig := new(mockAppConfigClient)
+		ctx := &AppConfigCloudContext{
+			svc:      mockAppConfig,
+			profiles: make(map[string]*appConfigProfile),
+		}
+
+		mockAppConfig.On("StartConfigurationSessionWithContext", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{
+			InitialConfigurationToken: aws.String("token-1"),
+		}, nil)
+		mockAppConfig.On("GetLatestConfigurationWithContext", mock.Anything).Return(&appconfigdata.GetLatestConfigurationOutput{
+			Configuration:              []byte(`{"limite": 10}`),
+			NextPollConfigurationToken: aws.String("token-2"),
+		}, nil)
+
+		for i := 0; i < maxAppConfigProfiles+10; i++ {
+			_, err := ctx.GetValue("consignado", "prod", fmt.Sprintf("limites-%d", i))
+			assert.NoError(t, err)
+		}
+		assert.Len(t, ctx.profiles, maxAppConfigProfiles)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 )
@@ -13,6 +14,12 @@ type SecretsManagerResource interface {
 	GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error)
 }
 
+// SecretsManagerContextResource é implementado pelos clientes que cancelam a consulta com o
+// contexto, como o cliente do SDK. Os demais clientes são consultados sem o contexto.
+type SecretsManagerContextResource interface {
+	GetSecretValueWithContext(ctx aws.Context, input *secretsmanager.GetSecretValueInput, opts ...request.Option) (*secretsmanager.GetSecretValueOutput, error)
+}
+
 // SecretsManagerCloudContext implementa CloudContext para Secrets Manager
 type SecretsManagerCloudContext struct {
 	svc SecretsManagerResource
@@ -26,11 +33,24 @@ func NewSecretsManagerContext(sess *session.Session) *SecretsManagerCloudContext
 
 // GetValue obtém e processa o segredo do Secrets Manager
 func (ctx *SecretsManagerCloudContext) GetValue(secretName, secretType string) (*string, error) {
+	return ctx.GetValueWithContext(aws.BackgroundContext(), secretName, secretType)
+}
+
+// GetValueWithContext obtém e processa o segredo do Secrets Manager, respeitando o cancelamento do contexto
+func (ctx *SecretsManagerCloudContext) GetValueWithContext(awsCtx aws.Context, secretName, secretType string) (*string, error) {
 	input := &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
 	}
 
-	result, err := ctx.svc.GetSecretValue(input)
+	var (
+		result *secretsmanager.GetSecretValueOutput
+		err    error
+	)
+	if svc, ok := ctx.svc.(SecretsManagerContextResource); ok {
+		result, err = svc.GetSecretValueWithContext(awsCtx, input)
+	} else {
+		result, err = ctx.svc.GetSecretValue(input)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error when obtaining secret: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -12,6 +13,12 @@ type SSMResource interface {
 	GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
 }
 
+// SSMContextResource é implementado pelos clientes que cancelam a consulta com o contexto,
+// como o cliente do SDK. Os demais clientes são consultados sem o contexto.
+type SSMContextResource interface {
+	GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error)
+}
+
 // SSMCloudContext implementa CloudContext para SSM Parameter Store
 type SSMCloudContext struct {
 	svc SSMResource
@@ -25,12 +32,25 @@ func NewSSMContext(sess *session.Session) *SSMCloudContext {
 
 // GetValue obtém o valor do parâmetro SSM
 func (ctx *SSMCloudContext) GetValue(parameterName string, withDecryption bool) (*string, error) {
+	return ctx.GetValueWithContext(aws.BackgroundContext(), parameterName, withDecryption)
+}
+
+// GetValueWithContext obtém o valor do parâmetro SSM, respeitando o cancelamento do contexto
+func (ctx *SSMCloudContext) GetValueWithContext(awsCtx aws.Context, parameterName string, withDecryption bool) (*string, error) {
 	input := &ssm.GetParameterInput{
 		Name:           aws.String(parameterName),
 		WithDecryption: aws.Bool(withDecryption),
 	}
 
-	result, err := ctx.svc.GetParameter(input)
+	var (
+		result *ssm.GetParameterOutput
+		err    error
+	)
+	if svc, ok := ctx.svc.(SSMContextResource); ok {
+		result, err = svc.GetParameterWithContext(awsCtx, input)
+	} else {
+		result, err = ctx.svc.GetParameter(input)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error when obtaining SSM parameters: %w", err)
 	}
@@ -1,9 +1,11 @@
 package connector
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +26,16 @@ func (m *mockSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetPara
 	return args.Get(0).(*ssm.GetParameterOutput), args.Error(1)
 }
 
+// Mock para SSM com suporte ao contexto da consulta
+type mockSSMContextClient struct {
+	mockSSMClient
+}
+
+func (m *mockSSMContextClient) GetParameterWithContext(ctx aws.Context, input *ssm.GetParameterInput, opts ...request.Option) (*ssm.GetParameterOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ssm.GetParameterOutput), args.Error(1)
+}
+
 var params = pClient{}
 
 func loadDefaultParameterVariables() {
@@ -53,4 +65,22 @@ func TestSSMCloudContext_GetValue(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, paramValue, *result)
 	})
+
+	t.Run("Use the context when the client supports it", func(t *testing.T) {
+		mockSSM := new(mockSSMContextClient)
+		ctx := &SSMCloudContext{svc: mockSSM}
+
+		type key struct{}
+		reqCtx := context.WithValue(context.Background(), key{}, "consulta")
+		mockSSM.On("GetParameterWithContext", reqCtx, mock.Anything).Return(&ssm.GetParameterOutput{
+			Parameter: &ssm.Parameter{Value: aws.String("valor")},
+		}, nil)
+
+		result, err := ctx.GetValueWithContext(reqCtx, "/test/param", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "valor", *result)
+		mockSSM.AssertNotCalled(t, "GetParameter", mock.Anything)
+		mockSSM.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,83 @@
+package connectors
+
+import (
+	"fmt"
+
+	"github.com/raywall/cloud-service-pack/go/adapters"
+)
+
+// CacheConfig contains the read-through cache settings of a connector
+type CacheConfig struct {
+	// TTL is how long a result is kept in the cache (e.g. "10m")
+	TTL string `json:"ttl"`
+
+	// MaxEntries limits the number of results kept in memory
+	MaxEntries int `json:"maxEntries"`
+
+	// NegativeTTL is how long an empty result is kept in the cache
+	NegativeTTL string `json:"negativeTtl"`
+
+	// Redis enables a second level cache shared by every instance of the API
+	Redis *CacheRedisConfig `json:"redis,omitempty"`
+}
+
+// CacheRedisConfig contains the connection settings of the second level cache
+type CacheRedisConfig struct {
+	Addrs              []string `json:"addrs"`
+	Username           string   `json:"username"`
+	Password           string   `json:"password"`
+	DB                 int      `json:"db"`
+	TLS                bool     `json:"tls"`
+	InsecureSkipVerify bool     `json:"insecureSkipVerify"`
+	Mode               string   `json:"mode"`
+	MasterName         string   `json:"masterName"`
+	SentinelUsername   string   `json:"sentinelUsername"`
+	SentinelPassword   string   `json:"sentinelPassword"`
+
+	// Prefix is prepended to the cache keys of the connector ("graphql:cache:<field>:" by default)
+	Prefix string `json:"prefix"`
+}
+
+// withCache wraps the adapter with the read-through cache of the connector
+func withCache(adapter adapters.Adapter, field string, config *CacheConfig) (adapters.CachedAdapter, error) {
+	var err error
+
+	policy := adapters.CachePolicy{
+		MaxEntries: config.MaxEntries,
+	}
+
+	if policy.TTL, err = parseDuration(config.TTL); err != nil {
+		return nil, fmt.Errorf("invalid cache ttl: %v", err)
+	}
+	if policy.TTL <= 0 {
+		return nil, fmt.Errorf("the cache requires a ttl")
+	}
+	if policy.NegativeTTL, err = parseDuration(config.NegativeTTL); err != nil {
+		return nil, fmt.Errorf("invalid cache negativeTtl: %v", err)
+	}
+
+	if redis := config.Redis; redis != nil {
+		prefix := redis.Prefix
+		if prefix == "" {
+			prefix = fmt.Sprintf("graphql:cache:%s:", field)
+		}
+
+		policy.Store, err = adapters.NewRedisCacheStore(adapters.RedisOptions{
+			Addrs:              redis.Addrs,
+			Username:           redis.Username,
+			Password:           redis.Password,
+			DB:                 redis.DB,
+			TLS:                redis.TLS,
+			InsecureSkipVerify: redis.InsecureSkipVerify,
+			Topology:           redis.Mode,
+			MasterName:         redis.MasterName,
+			SentinelUsername:   redis.SentinelUsername,
+			SentinelPassword:   redis.SentinelPassword,
+		}, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache redis settings: %v", err)
+		}
+	}
+
+	return adapters.NewCachedAdapter(adapter, policy), nil
+}
@@ -1,8 +1,12 @@
 package connectors
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/raywall/cloud-service-pack/go/adapters"
 	"github.com/raywall/cloud-service-pack/go/graphql/types"
@@ -13,6 +17,23 @@ type ConnectorConfig struct {
 	Adapter       string                 `json:"adapter"`
 	AdapterConfig map[string]interface{} `json:"adapterConfig"`
 	KeyPattern    string                 `json:"keyPattern"`
+
+	// Timeout limits how long the adapter may take to answer a single call
+	// (e.g. "500ms", "2s"). When empty, only the request deadline applies.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Resilience enables retries and the circuit breaker for the connector
+	Resilience *ResilienceConfig `json:"resilience,omitempty"`
+
+	// Cache enables the read-through cache of the connector results
+	Cache *CacheConfig `json:"cache,omitempty"`
+
+	// Mapping reshapes the result of the adapter into the fields of the GraphQL type
+	Mapping *MappingConfig `json:"mapping,omitempty"`
+
+	// Coalesce collapses identical concurrent calls into a single upstream call.
+	// It is enabled unless explicitly set to false.
+	Coalesce *bool `json:"coalesce,omitempty"`
 }
 
 type Config struct {
@@ -20,73 +41,159 @@ type Config struct {
 }
 
 type Connector interface {
-	GetData(args map[string]interface{}) (interface{}, error)
+	GetData(ctx context.Context, args map[string]interface{}) (interface{}, error)
+
+	// Invalidate removes the cached result of the arguments
+	Invalidate(ctx context.Context, args map[string]interface{}) error
+
+	// Purge removes every cached result of the connector
+	Purge(ctx context.Context) error
+
+	// Stats returns the counters of the connector
+	Stats() Stats
+
+	// Health checks whether the dependency of the connector is reachable
+	Health(ctx context.Context) Health
+
+	// Close stops the background work of the adapter (e.g. the STS token refresh)
+	Close() error
+}
+
+// Stats contains the counters of a connector
+type Stats struct {
+	// Deduplicated is the number of calls answered by an identical call in flight
+	Deduplicated int64 `json:"deduplicated"`
 }
 
+// ErrCacheDisabled is returned when the cache of a connector without cache is invalidated
+var ErrCacheDisabled = errors.New("the connector cache is not enabled")
+
 type connector struct {
 	adapter    adapters.Adapter
+	cache      adapters.CachedAdapter
+	coalescer  adapters.CoalescingAdapter
 	keyPattern string
+	timeout    time.Duration
 }
 
-func NewConnector(cfg *types.Config, config ConnectorConfig) (Connector, error) {
-	var adapter adapters.Adapter
-	attributes := config.AdapterConfig["attr"].(map[string]interface{})
+func NewConnector(cfg *types.Config, config ConnectorConfig, logger *slog.Logger) (Connector, error) {
+	timeout, err := parseDuration(config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout %q: %v", config.Timeout, err)
+	}
 
-	switch config.Adapter {
-	case "redis":
-		endpoint, _ := config.AdapterConfig["endpoint"].(string)
-		password, _ := config.AdapterConfig["password"].(string)
-		adapter = adapters.NewRedisAdapter(endpoint, password, config.KeyPattern, attributes)
+	if logger == nil {
+		logger = slog.Default()
+	}
 
-	case "rest":
-		headers := make(map[string]interface{})
-		auth := false
+	adapter, err := adapters.New(config.Adapter, adapters.Settings{
+		Config:     cfg,
+		KeyPattern: config.KeyPattern,
+		Raw:        config.AdapterConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if config.AdapterConfig["auth"] != nil {
-			auth, _ = config.AdapterConfig["auth"].(bool)
-		}
+	// the results of the connectors forwarding the caller token belong to the
+	// caller, so they are neither cached nor shared between concurrent calls
+	forwards := adapters.ForwardsCallerToken(adapter)
+	if forwards && config.Cache != nil {
+		adapters.CloseAdapter(adapter)
+		return nil, fmt.Errorf("the cache cannot be enabled for connectors forwarding the caller token")
+	}
 
-		if config.AdapterConfig["headers"] != nil {
-			headers = config.AdapterConfig["headers"].(map[string]interface{})
+	if config.Resilience != nil {
+		wrapped, err := withResilience(adapter, config.Field, config.Resilience, logger)
+		if err != nil {
+			adapters.CloseAdapter(adapter)
+			return nil, err
 		}
+		adapter = wrapped
+	}
 
-		baseUrl, _ := config.AdapterConfig["baseUrl"].(string)
-		endpoint, _ := config.AdapterConfig["endpoint"].(string)
-		adapter = adapters.NewRestAdapter(cfg, baseUrl, endpoint, auth, attributes, headers)
-
-	case "s3":
-		region, _ := config.AdapterConfig["region"].(string)
-		bucket, _ := config.AdapterConfig["bucket"].(string)
-		accessKeyId, _ := config.AdapterConfig["accessKeyId"].(string)
-		secretAccessKey, _ := config.AdapterConfig["secretAccessKey"].(string)
-		adapter = adapters.NewS3Adapter(region, bucket, accessKeyId, secretAccessKey)
-
-	case "dynamodb":
-		region, _ := config.AdapterConfig["region"].(string)
-		table, _ := config.AdapterConfig["table"].(string)
-		accessKeyId, _ := config.AdapterConfig["accessKeyId"].(string)
-		secretAccessKey, _ := config.AdapterConfig["secretAccessKey"].(string)
-		adapter = adapters.NewDynamoDBAdapter(region, table, accessKeyId, secretAccessKey)
+	// the mapping runs once per upstream result, outside the retries
+	if config.Mapping != nil {
+		wrapped, err := withMapping(adapter, config.Mapping)
+		if err != nil {
+			adapters.CloseAdapter(adapter)
+			return nil, err
+		}
+		adapter = wrapped
+	}
 
-	default:
-		return nil, fmt.Errorf("unsupported adapter: %s", config.Adapter)
+	// identical concurrent calls are collapsed before reaching the retries
+	var coalescer adapters.CoalescingAdapter
+	if (config.Coalesce == nil || *config.Coalesce) && !forwards {
+		coalescer = adapters.NewCoalescingAdapterWithTimeout(adapter, timeout)
+		adapter = coalescer
 	}
 
-	return &connector{
+	conn := &connector{
 		adapter:    adapter,
+		coalescer:  coalescer,
 		keyPattern: config.KeyPattern,
-	}, nil
+		timeout:    timeout,
+	}
+
+	// the cache is the outermost layer, so that hits skip retries and the circuit breaker
+	if config.Cache != nil {
+		if conn.cache, err = withCache(adapter, config.Field, config.Cache); err != nil {
+			adapters.CloseAdapter(adapter)
+			return nil, err
+		}
+		conn.adapter = conn.cache
+	}
+
+	return conn, nil
 }
 
-func (c *connector) GetData(args map[string]interface{}) (interface{}, error) {
+func (c *connector) GetData(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	if params, err := c.adapter.GetParameters(args); err != nil {
 		return nil, err
 	} else {
-		return c.adapter.GetData(params)
+		return c.adapter.GetData(ctx, params)
+	}
+}
+
+func (c *connector) Invalidate(ctx context.Context, args map[string]interface{}) error {
+	if c.cache == nil {
+		return ErrCacheDisabled
+	}
+
+	params, err := c.adapter.GetParameters(args)
+	if err != nil {
+		return err
+	}
+	return c.cache.Invalidate(ctx, params)
+}
+
+func (c *connector) Purge(ctx context.Context) error {
+	if c.cache == nil {
+		return ErrCacheDisabled
+	}
+	return c.cache.Purge(ctx)
+}
+
+func (c *connector) Close() error {
+	return adapters.CloseAdapter(c.adapter)
+}
+
+func (c *connector) Stats() Stats {
+	var stats Stats
+	if c.coalescer != nil {
+		stats.Deduplicated = c.coalescer.Deduplicated()
 	}
+	return stats
 }
 
-func LoadConnectors(cfg *types.Config, connectorConfig string) (map[string]Connector, error) {
+func LoadConnectors(cfg *types.Config, connectorConfig string, logger *slog.Logger) (map[string]Connector, error) {
 	var config Config
 	if err := json.Unmarshal([]byte(connectorConfig), &config); err != nil {
 		return nil, fmt.Errorf("error parsing connectors config: %v", err)
@@ -94,8 +201,11 @@ func LoadConnectors(cfg *types.Config, connectorConfig string) (map[string]Conne
 
 	connectors := make(map[string]Connector)
 	for _, connConfig := range config.Connectors {
-		conn, err := NewConnector(cfg, connConfig)
+		conn, err := NewConnector(cfg, connConfig, logger)
 		if err != nil {
+			for _, created := range connectors {
+				created.Close()
+			}
 			return nil, fmt.Errorf("error creating connector for %s: %v", connConfig.Field, err)
 		}
 		connectors[connConfig.Field] = conn
@@ -0,0 +1,43 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/raywall/cloud-service-pack/go/adapters"
+)
+
+// Health statuses of a connector
+const (
+	HealthUp      = "up"
+	HealthDown    = "down"
+	HealthUnknown = "unknown"
+)
+
+// Health is the result of the health check of a connector dependency
+type Health struct {
+	// Status is up, down or unknown when the adapter cannot check its dependency
+	Status string
+
+	// Latency is how long the check took
+	Latency time.Duration
+
+	// Error is the reason of a failed check
+	Error string
+}
+
+func (c *connector) Health(ctx context.Context) Health {
+	start := time.Now()
+	err := adapters.CheckHealth(ctx, c.adapter)
+	health := Health{Status: HealthUp, Latency: time.Since(start)}
+
+	switch {
+	case errors.Is(err, adapters.ErrHealthCheckUnsupported):
+		health.Status = HealthUnknown
+	case err != nil:
+		health.Status = HealthDown
+		health.Error = err.Error()
+	}
+	return health
+}
@@ -0,0 +1,70 @@
+package connectors
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/raywall/cloud-service-pack/go/adapters"
+)
+
+// MappingConfig reshapes the result of a connector into the fields of its GraphQL type
+type MappingConfig struct {
+	// Fields maps each field of the GraphQL type to the way it is read from the
+	// upstream. A string is a shorthand for the path (e.g. {"nome": "nm_convenio"}).
+	Fields map[string]MappingFieldConfig `json:"fields"`
+
+	// KeepUnmapped copies every field of the upstream to the result before
+	// the mapped fields are applied
+	KeepUnmapped bool `json:"keepUnmapped"`
+
+	// ItemsPath selects the items mapped inside an envelope, such as the hits of
+	// the OpenSearch results or the items of the REST passthrough pages
+	ItemsPath string `json:"itemsPath,omitempty"`
+}
+
+// MappingFieldConfig contains the settings of a mapped field
+type MappingFieldConfig struct {
+	// Path selects the value in the upstream item (e.g. "$.limites.disponivel").
+	// When empty, the field with the same name is read.
+	Path string `json:"path"`
+
+	// Default is the value used when the path is missing or null
+	Default interface{} `json:"default,omitempty"`
+
+	// Type casts the value (e.g. "Int", "Float!" or "[String]")
+	Type string `json:"type"`
+}
+
+// UnmarshalJSON accepts either the field settings or the path alone
+func (f *MappingFieldConfig) UnmarshalJSON(content []byte) error {
+	var path string
+	if err := json.Unmarshal(content, &path); err == nil {
+		*f = MappingFieldConfig{Path: path}
+		return nil
+	}
+
+	type field MappingFieldConfig
+	return json.Unmarshal(content, (*field)(f))
+}
+
+// withMapping wraps the adapter with the result mapping of the connector
+func withMapping(adapter adapters.Adapter, config *MappingConfig) (adapters.Adapter, error) {
+	policy := adapters.MappingPolicy{
+		Fields:       make(map[string]adapters.MappingField, len(config.Fields)),
+		KeepUnmapped: config.KeepUnmapped,
+		ItemsPath:    config.ItemsPath,
+	}
+	for name, field := range config.Fields {
+		policy.Fields[name] = adapters.MappingField{
+			Path:    field.Path,
+			Default: field.Default,
+			Type:    field.Type,
+		}
+	}
+
+	mapped, err := adapters.NewMappingAdapter(adapter, policy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mapping: %v", err)
+	}
+	return mapped, nil
+}
@@ -0,0 +1,96 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/raywall/cloud-service-pack/go/adapters"
+)
+
+// ResilienceConfig contains the retry and circuit breaker settings of a connector
+type ResilienceConfig struct {
+	// MaxAttempts is the total number of calls made to the adapter before giving up
+	MaxAttempts int `json:"maxAttempts"`
+
+	// InitialBackoff is the delay before the first retry (e.g. "100ms")
+	InitialBackoff string `json:"initialBackoff"`
+
+	// MaxBackoff limits the delay between two attempts (e.g. "2s")
+	MaxBackoff string `json:"maxBackoff"`
+
+	// Multiplier is applied to the delay after every retry
+	Multiplier float64 `json:"multiplier"`
+
+	// Jitter is the fraction (0 to 1) of the delay that is randomized (0.2 by
+	// default, a negative value disables it)
+	Jitter float64 `json:"jitter"`
+
+	// RetryableStatus lists the upstream status codes that can be retried
+	RetryableStatus []int `json:"retryableStatus,omitempty"`
+
+	// CircuitBreaker enables the circuit breaker of the connector
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerConfig contains the circuit breaker settings of a connector
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int `json:"failureThreshold"`
+
+	// OpenTimeout is how long the circuit stays open before probing (e.g. "30s")
+	OpenTimeout string `json:"openTimeout"`
+
+	// HalfOpenMaxCalls is the number of concurrent probes allowed while half-open
+	HalfOpenMaxCalls int `json:"halfOpenMaxCalls"`
+}
+
+// withResilience wraps the adapter with the retry and circuit breaker policy
+// of the connector, logging every circuit state change
+func withResilience(adapter adapters.Adapter, field string, config *ResilienceConfig, logger *slog.Logger) (adapters.Adapter, error) {
+	var err error
+
+	policy := adapters.ResiliencePolicy{
+		MaxAttempts:     config.MaxAttempts,
+		Multiplier:      config.Multiplier,
+		Jitter:          config.Jitter,
+		RetryableStatus: config.RetryableStatus,
+	}
+
+	if policy.InitialBackoff, err = parseDuration(config.InitialBackoff); err != nil {
+		return nil, fmt.Errorf("invalid initialBackoff: %v", err)
+	}
+	if policy.MaxBackoff, err = parseDuration(config.MaxBackoff); err != nil {
+		return nil, fmt.Errorf("invalid maxBackoff: %v", err)
+	}
+
+	if breaker := config.CircuitBreaker; breaker != nil {
+		policy.Breaker = &adapters.BreakerPolicy{
+			FailureThreshold: breaker.FailureThreshold,
+			HalfOpenMaxCalls: breaker.HalfOpenMaxCalls,
+		}
+		if policy.Breaker.OpenTimeout, err = parseDuration(breaker.OpenTimeout); err != nil {
+			return nil, fmt.Errorf("invalid openTimeout: %v", err)
+		}
+
+		policy.OnStateChange = func(from, to adapters.CircuitState) {
+			level := slog.LevelInfo
+			if to == adapters.CircuitOpen {
+				level = slog.LevelWarn
+			}
+			logger.Log(context.Background(), level, fmt.Sprintf("circuit breaker of %s changed to %s", field, to),
+				"field", field, "from", from, "to", to)
+		}
+	}
+
+	return adapters.NewResilientAdapter(adapter, policy), nil
+}
+
+// parseDuration converts an optional duration, returning zero when it is empty
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
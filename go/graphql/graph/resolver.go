@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/raywall/cloud-service-pack/go/adapters"
 	"github.com/raywall/cloud-service-pack/go/graphql/graph/connectors"
 	"github.com/raywall/cloud-service-pack/go/graphql/types"
 )
@@ -16,6 +18,21 @@ import (
 type Resolver interface {
 	ResolveDataSource(p graphql.ResolveParams) (interface{}, error)
 	AddConfig(cfg *types.Config) error
+
+	// InvalidateCache removes the result cached by the connector of the field for the arguments
+	InvalidateCache(ctx context.Context, field string, args map[string]interface{}) error
+
+	// PurgeCache removes every result cached by the connector of the field
+	PurgeCache(ctx context.Context, field string) error
+
+	// Stats returns the counters of every connector, by field
+	Stats() map[string]connectors.Stats
+
+	// Health checks the dependencies of every connector concurrently, by field
+	Health(ctx context.Context) map[string]connectors.Health
+
+	// Close stops the background work of every connector
+	Close() error
 }
 
 type resolver struct {
@@ -32,14 +49,16 @@ type mockResolver struct {
 }
 
 func NewResolver(cfg *types.Config, connectorConfig string) (Resolver, error) {
-	connectors, err := connectors.LoadConnectors(cfg, connectorConfig)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	connectors, err := connectors.LoadConnectors(cfg, connectorConfig, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	return &resolver{
 		dataConnectors: connectors,
-		logger:         slog.New(slog.NewJSONHandler(os.Stdout, nil)),
+		logger:         logger,
 		mock: &mockResolver{
 			Status: false,
 			Values: nil,
@@ -66,11 +85,15 @@ func (r *resolver) ResolveDataSource(p graphql.ResolveParams) (interface{}, erro
 		requestedFields = getRequestedFields(p.Info)
 		errChan         = make(chan error, len(requestedFields))
 		wg              sync.WaitGroup
+		mu              sync.Mutex
+		ctx             = p.Context
 	)
 
-	// Context for timeout/cancellation control
-	// ctx, cancel := context.WithCancel(context.Background())
-	// defer cancel()
+	// The request context carries the client cancellation and deadline, which
+	// are propagated to every connector call
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	for _, field := range requestedFields {
 		conn, exists := r.dataConnectors[field]
@@ -89,8 +112,23 @@ func (r *resolver) ResolveDataSource(p graphql.ResolveParams) (interface{}, erro
 		wg.Add(1)
 		go func(field string, conn connectors.Connector) {
 			defer wg.Done()
-			data, err := conn.GetData(p.Args)
+			data, err := conn.GetData(ctx, p.Args)
 			if err != nil {
+				// Upstream status codes mapped by the connector, invalid arguments and
+				// errors of remote GraphQL services are reported to the client
+				var (
+					statusErr  *adapters.StatusError
+					argErr     *adapters.ArgumentError
+					graphqlErr *adapters.GraphQLError
+				)
+				if errors.As(err, &graphqlErr) {
+					r.logger.Warn(fmt.Sprintf("error fetching %s", field), "url", graphqlErr.URL, "error", err)
+				}
+				if errors.As(err, &statusErr) || errors.As(err, &argErr) || graphqlErr != nil {
+					errChan <- fmt.Errorf("error fetching %s: \n\t%w", field, err)
+					return
+				}
+
 				// Log the error instead of sending it to the error channel
 				r.logger.Error(fmt.Sprintf("error fetching %s", field), "error", err)
 				return
@@ -102,7 +140,9 @@ func (r *resolver) ResolveDataSource(p graphql.ResolveParams) (interface{}, erro
 				// }
 				// return
 			}
+			mu.Lock()
 			result[field] = data
+			mu.Unlock()
 		}(field, conn)
 	}
 
@@ -120,6 +160,63 @@ func (r *resolver) ResolveDataSource(p graphql.ResolveParams) (interface{}, erro
 	return result, nil
 }
 
+func (r *resolver) InvalidateCache(ctx context.Context, field string, args map[string]interface{}) error {
+	conn, exists := r.dataConnectors[field]
+	if !exists {
+		return fmt.Errorf("no connector found for field: %s", field)
+	}
+	return conn.Invalidate(ctx, args)
+}
+
+func (r *resolver) PurgeCache(ctx context.Context, field string) error {
+	conn, exists := r.dataConnectors[field]
+	if !exists {
+		return fmt.Errorf("no connector found for field: %s", field)
+	}
+	return conn.Purge(ctx)
+}
+
+func (r *resolver) Stats() map[string]connectors.Stats {
+	stats := make(map[string]connectors.Stats, len(r.dataConnectors))
+	for field, conn := range r.dataConnectors {
+		stats[field] = conn.Stats()
+	}
+	return stats
+}
+
+func (r *resolver) Health(ctx context.Context) map[string]connectors.Health {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		health = make(map[string]connectors.Health, len(r.dataConnectors))
+	)
+
+	for field, conn := range r.dataConnectors {
+		wg.Add(1)
+		go func(field string, conn connectors.Connector) {
+			defer wg.Done()
+
+			result := conn.Health(ctx)
+			mu.Lock()
+			health[field] = result
+			mu.Unlock()
+		}(field, conn)
+	}
+
+	wg.Wait()
+	return health
+}
+
+func (r *resolver) Close() error {
+	var errs []error
+	for field, conn := range r.dataConnectors {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the connector of %s: %v", field, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func getRequestedFields(info graphql.ResolveInfo) []string {
 	fields := make([]string, 0)
 
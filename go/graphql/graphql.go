@@ -124,3 +124,12 @@ func New(config *types.Config, resources *cloud.CloudContextList, region, endpoi
 
 	return &api, nil
 }
+
+// Close stops the background work of the connectors, such as the token refresh
+// of their sts auth profiles
+func (g *GraphQL) Close() error {
+	if g.Resolver == nil {
+		return nil
+	}
+	return (*g.Resolver).Close()
+}
@@ -20,6 +20,7 @@ func (g *GraphQL) NewHandler(pretty bool, middlewares ...middleware.Middleware)
 	// Aplicar middleware chain
 	return middleware.Chain(
 		h,
+		middleware.CallerToken,
 		// middleware.Logging,
 		// middleware.Tracing,
 	)
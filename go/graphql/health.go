@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/raywall/cloud-service-pack/go/graphql/graph/connectors"
+)
+
+// defaultHealthTimeout limits the readiness checks when no timeout is informed,
+// below the 5 seconds an ALB health check waits by default
+const defaultHealthTimeout = 2 * time.Second
+
+// healthReport is the body returned by the liveness and readiness handlers
+type healthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]healthCheck `json:"checks,omitempty"`
+}
+
+// healthCheck is the health of the dependency of a connector
+type healthCheck struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// NewLivenessHandler returns a handler that answers 200 while the process is
+// able to serve requests, without checking the dependencies
+func (g *GraphQL) NewLivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, http.StatusOK, healthReport{Status: connectors.HealthUp})
+	})
+}
+
+// NewReadinessHandler returns a handler that checks the dependencies of every
+// connector, answering 503 when any of them is down. Connectors whose adapter
+// cannot be checked are reported as unknown and do not affect the status.
+func (g *GraphQL) NewReadinessHandler(timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		report := healthReport{
+			Status: connectors.HealthUp,
+			Checks: make(map[string]healthCheck),
+		}
+		if g.Resolver != nil {
+			for field, health := range (*g.Resolver).Health(ctx) {
+				report.Checks[field] = healthCheck{
+					Status:    health.Status,
+					LatencyMs: float64(health.Latency.Microseconds()) / 1000,
+					Error:     health.Error,
+				}
+				if health.Status == connectors.HealthDown {
+					report.Status = connectors.HealthDown
+				}
+			}
+		}
+
+		status := http.StatusOK
+		if report.Status == connectors.HealthDown {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealth(w, status, report)
+	})
+}
+
+func writeHealth(w http.ResponseWriter, status int, report healthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -4,6 +4,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/raywall/cloud-service-pack/go/adapters"
 )
 
 type Middleware func(http.Handler) http.Handler
@@ -24,6 +26,17 @@ func Logging(next http.Handler) http.Handler {
 	})
 }
 
+// CallerToken stores the Authorization header of the request in its context, so
+// that the connectors with a forward auth profile send it to their upstreams
+func CallerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token != "" {
+			r = r.WithContext(adapters.WithCallerToken(r.Context(), token))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func Tracing(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Add tracing logic here (e.g., extract/propagate trace context)
@@ -65,11 +65,54 @@ type Authorization struct {
 	TokenService TokenService
 }
 
+// Authentication methods of the auth profiles
+const (
+	AuthSTS     = "sts"
+	AuthAPIKey  = "apikey"
+	AuthBasic   = "basic"
+	AuthSigV4   = "sigv4"
+	AuthForward = "forward"
+)
+
+// AuthProfile contains the settings of a named authentication profile, referenced
+// by the connectors through the "authProfile" setting. The secrets accept inline
+// configurations (e.g. aws::secrets::my-secret-name).
+type AuthProfile struct {
+	// Type is the authentication method: sts (client credentials), apikey,
+	// basic, sigv4 or forward (the token received from the caller)
+	Type string `json:"type"`
+
+	// TokenService contains the STS settings of the sts profiles
+	TokenService TokenService `json:"tokenService"`
+
+	// Header is the header of the api key (X-API-Key by default) or of the
+	// forwarded token (Authorization by default)
+	Header string `json:"header"`
+
+	// Key is the api key sent in the header
+	Key string `json:"key"`
+
+	// Username and Password are the credentials of the basic profiles
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// Region, Service, AccessKeyId and SecretAccessKey are the signature settings
+	// of the sigv4 profiles. Without keys, the default credential chain is used.
+	Region          string `json:"region"`
+	Service         string `json:"service"`
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
 // Config contains all the configuration required to create and instantiate a dynamic GraphQL API
 type Config struct {
 	// Authorization contains the authorization settings to be used by GraphQL API connectors
 	Authorization Authorization
 
+	// AuthProfiles are the named authentication profiles of the connectors, which
+	// call upstreams of different token issuers and audiences
+	AuthProfiles map[string]AuthProfile `json:"authProfiles"`
+
 	// BasicData is the basic information necessary to register the library observability
 	BasicData *Info `json:"basic"`
 
@@ -197,3 +240,39 @@ func (c *Config) GetCredentials() (string, string, error) {
 
 	return clientID, clientSecret, nil
 }
+
+// GetAuthProfile returns the authentication profile with the name informed,
+// resolving the inline configurations of its secrets
+func (c *Config) GetAuthProfile(name string) (AuthProfile, error) {
+	profile, exists := c.AuthProfiles[name]
+	if !exists {
+		return profile, fmt.Errorf("the auth profile %s does not exist", name)
+	}
+
+	for _, field := range []*string{
+		&profile.TokenService.TokenAuthorizationURL,
+		&profile.TokenService.Credentials.ClientID,
+		&profile.TokenService.Credentials.ClientSecret,
+		&profile.Key,
+		&profile.Username,
+		&profile.Password,
+		&profile.AccessKeyId,
+		&profile.SecretAccessKey,
+	} {
+		if !data.IsConfig(*field) {
+			continue
+		}
+
+		cfg, err := data.ParseConfig(*field)
+		if err != nil {
+			return profile, fmt.Errorf("failed to get inline configuration of auth profile %s: %v", name, err)
+		}
+		value, err := data.GetValue(cfg, c.Session)
+		if err != nil {
+			return profile, fmt.Errorf("failed to get the secret of auth profile %s: %v", name, err)
+		}
+		*field = string(value)
+	}
+
+	return profile, nil
+}